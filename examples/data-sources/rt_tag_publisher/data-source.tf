@@ -0,0 +1,7 @@
+data "rt_tag_publisher" "platform" {
+  id = rt_tag_publisher.platform.id
+}
+
+output "publisher_status" {
+  value = data.rt_tag_publisher.platform.status
+}
@@ -0,0 +1,5 @@
+ephemeral "rt_terraform_token" "deploy" {
+  namespace_id = rt_namespace.platform.id
+  role         = "provisioner"
+  expires_in   = "15m"
+}
@@ -0,0 +1,3 @@
+# Terraform tokens can be imported using the service account ID and the token ID,
+# separated by a slash. The secret token value cannot be recovered after import.
+terraform import rt_terraform_token.example <service_account_id>/<token_id>
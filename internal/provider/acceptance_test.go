@@ -28,18 +28,23 @@ func TestAccRTProvider(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testInitialConfig(rand, "5m", githubToken),
+				Config: testInitialConfig(rand, "5m", githubToken, "test github connector", "v*"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("rt_terraform_token.this", "role", "provisioner"),
 					resource.TestCheckResourceAttr("rt_terraform_token.this", "expires_in", "5m"),
 					resource.TestCheckResourceAttrSet("rt_terraform_token.this", "id"),
 					resource.TestCheckResourceAttrSet("rt_terraform_token.this", "expires_at"),
+					resource.TestCheckResourceAttrSet("rt_terraform_token.this", "service_account_id"),
+					resource.TestCheckResourceAttrSet("rt_terraform_token.this", "service_account_name"),
 					resource.TestCheckResourceAttrSet("rt_tag_publisher.this", "id"),
+					resource.TestCheckResourceAttrSet("rt_tag_publisher.this", "status"),
+					resource.TestCheckResourceAttrPair("data.rt_tag_publisher.this", "repo_identifier", "rt_tag_publisher.this", "repo_identifier"),
+					resource.TestCheckResourceAttrSet("data.rt_tag_publisher.this", "status"),
 				),
 			},
 			// Update and Read testing
 			{
-				Config: testInitialConfig(rand, "10m", githubToken),
+				Config: testInitialConfig(rand, "10m", githubToken, "test github connector", "v*"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("rt_terraform_token.this", "expires_in", "10m"),
 				),
@@ -51,10 +56,61 @@ func TestAccRTProvider(t *testing.T) {
 					},
 				},
 			},
+			// In-place connector update testing
+			{
+				Config: testInitialConfig(rand, "10m", githubToken, "updated github connector", "v*"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("rt_vcs_connector.this", "description", "updated github connector"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("rt_vcs_connector.this", plancheck.ResourceActionUpdate),
+						plancheck.ExpectResourceAction("rt_tag_publisher.this", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			// In-place tag publisher update testing
+			{
+				Config: testInitialConfig(rand, "10m", githubToken, "updated github connector", "v1.*"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("rt_tag_publisher.this", "backfill_pattern", "v1.*"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("rt_tag_publisher.this", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:            "rt_terraform_token.this",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccTerraformTokenImportStateID("rt_terraform_token.this"),
+				ImportStateVerifyIgnore: []string{"expires_in", "token"},
+			},
+			{
+				ResourceName:      "rt_tag_publisher.this",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The sync status keeps changing while the tag publisher runs
+				ImportStateVerifyIgnore: []string{"status", "webhook_active", "last_published_tag", "last_published_at", "published_version_count", "last_error"},
+			},
 		}})
 }
 
-func testInitialConfig(rand int64, expiration string, githubToken string) string {
+func testAccTerraformTokenImportStateID(resourceName string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Resource %s not found in state", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["service_account_id"], rs.Primary.ID), nil
+	}
+}
+
+func testInitialConfig(rand int64, expiration string, githubToken string, connectorDescription string, backfillPattern string) string {
 	return fmt.Sprintf(`
 resource "rt_namespace" "this" {
   name = "default-%[1]d"
@@ -68,7 +124,7 @@ resource "rt_terraform_token" "this" {
 }
 
 resource "rt_vcs_connector" "this" {
-	description = "test github connector"
+	description = "%[4]s"
 	github = {
 		token = "%[3]s"
 	}
@@ -78,8 +134,13 @@ resource "rt_tag_publisher" "this" {
 	vcs_connector_id = rt_vcs_connector.this.id
 	repo_identifier = "registry-tools/terraform-rt-private-registry"
 	namespace_id = rt_namespace.this.id
+	backfill_pattern = "%[5]s"
+}
+
+data "rt_tag_publisher" "this" {
+	id = rt_tag_publisher.this.id
 }
-`, rand, expiration, githubToken)
+`, rand, expiration, githubToken, connectorDescription, backfillPattern)
 }
 
 func testSDKClientFromENV() (sdk.SDK, error) {
@@ -1,7 +1,10 @@
 package provider
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/registry-tools/rt-sdk/generated/models"
 )
 
@@ -15,6 +18,17 @@ func APIErrorsAsDiagnostics(err error, diags *diag.Diagnostics) {
 	}
 }
 
+// APIErrorsAsWarnings reports API errors as warnings on the attribute, for
+// checks which must not fail the plan.
+func APIErrorsAsWarnings(err error, attributePath path.Path, summary string, diags *diag.Diagnostics) {
+	var errorDiags diag.Diagnostics
+	APIErrorsAsDiagnostics(err, &errorDiags)
+
+	for _, d := range errorDiags.Errors() {
+		diags.AddAttributeWarning(attributePath, summary, fmt.Sprintf("%s: %s", d.Summary(), d.Detail()))
+	}
+}
+
 func IsNotFoundError(err error) bool {
 	if modelError, ok := err.(*models.Errors); ok {
 		return modelError.ResponseStatusCode == 404
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+const keybasePrefix = "keybase:"
+
+// keybaseClient fetches keys from keybase.io. The timeout stops an
+// unresponsive keybase server from stalling the apply.
+var keybaseClient = &http.Client{Timeout: 30 * time.Second}
+
+// resolvePGPKey returns the public key entity for a pgp_key value. The value
+// may be an ASCII armored public key, a base64 encoded binary public key, or
+// a keybase:<username> reference, which is fetched from keybase.io.
+func resolvePGPKey(ctx context.Context, pgpKey string) (*openpgp.Entity, error) {
+	pgpKey = strings.TrimSpace(pgpKey)
+
+	if username, ok := strings.CutPrefix(pgpKey, keybasePrefix); ok {
+		armored, err := fetchKeybasePGPKey(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		pgpKey = armored
+	}
+
+	var entities openpgp.EntityList
+	var err error
+	if strings.HasPrefix(pgpKey, "-----BEGIN PGP") {
+		entities, err = openpgp.ReadArmoredKeyRing(strings.NewReader(pgpKey))
+	} else {
+		var keyBytes []byte
+		keyBytes, err = base64.StdEncoding.DecodeString(pgpKey)
+		if err != nil {
+			return nil, fmt.Errorf("the key is neither ASCII armored, base64 encoded, nor a keybase reference: %w", err)
+		}
+		entities, err = openpgp.ReadKeyRing(bytes.NewReader(keyBytes))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the PGP public key: %w", err)
+	}
+
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("expected exactly one PGP public key, got %d", len(entities))
+	}
+
+	return entities[0], nil
+}
+
+func fetchKeybasePGPKey(ctx context.Context, username string) (string, error) {
+	if username == "" {
+		return "", errors.New("the keybase username is empty")
+	}
+
+	keyURL := fmt.Sprintf("https://keybase.io/%s/pgp_keys.asc", url.PathEscape(username))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, keyURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := keybaseClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not fetch the PGP key for keybase user %s: %w", username, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not fetch the PGP key for keybase user %s: %s", username, res.Status)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("could not read the PGP key for keybase user %s: %w", username, err)
+	}
+
+	return string(body), nil
+}
+
+// encryptWithPGPKey encrypts the plaintext for the entity and returns the
+// base64 encoded message along with the hex fingerprint of the key.
+func encryptWithPGPKey(entity *openpgp.Entity, plaintext string) (string, string, error) {
+	var ciphertext bytes.Buffer
+
+	w, err := openpgp.Encrypt(&ciphertext, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("could not encrypt with the PGP key: %w", err)
+	}
+
+	if _, err := w.Write([]byte(plaintext)); err != nil {
+		return "", "", fmt.Errorf("could not encrypt with the PGP key: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return "", "", fmt.Errorf("could not encrypt with the PGP key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(ciphertext.Bytes()), hex.EncodeToString(entity.PrimaryKey.Fingerprint), nil
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+func testPGPEntity(t *testing.T) (*openpgp.Entity, string, string) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("Test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatalf("Could not generate a PGP key: %v", err)
+	}
+
+	var binary bytes.Buffer
+	if err := entity.Serialize(&binary); err != nil {
+		t.Fatalf("Could not serialize the PGP key: %v", err)
+	}
+
+	var armored bytes.Buffer
+	w, err := armor.Encode(&armored, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("Could not armor the PGP key: %v", err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatalf("Could not serialize the PGP key: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Could not armor the PGP key: %v", err)
+	}
+
+	return entity, armored.String(), base64.StdEncoding.EncodeToString(binary.Bytes())
+}
+
+func TestEncryptWithPGPKey(t *testing.T) {
+	private, armored, encoded := testPGPEntity(t)
+
+	for name, key := range map[string]string{"armored": armored, "base64": encoded} {
+		t.Run(name, func(t *testing.T) {
+			entity, err := resolvePGPKey(context.Background(), key)
+			if err != nil {
+				t.Fatalf("Unexpected error resolving the key: %v", err)
+			}
+
+			encrypted, fingerprint, err := encryptWithPGPKey(entity, "secret-token")
+			if err != nil {
+				t.Fatalf("Unexpected error encrypting: %v", err)
+			}
+
+			if expected := hex.EncodeToString(private.PrimaryKey.Fingerprint); fingerprint != expected {
+				t.Fatalf("Expected fingerprint %s, got %s", expected, fingerprint)
+			}
+
+			ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+			if err != nil {
+				t.Fatalf("Encrypted token is not base64 encoded: %v", err)
+			}
+
+			md, err := openpgp.ReadMessage(bytes.NewReader(ciphertext), openpgp.EntityList{private}, nil, nil)
+			if err != nil {
+				t.Fatalf("Could not decrypt the token: %v", err)
+			}
+
+			plaintext, err := io.ReadAll(md.UnverifiedBody)
+			if err != nil {
+				t.Fatalf("Could not read the decrypted token: %v", err)
+			}
+
+			if string(plaintext) != "secret-token" {
+				t.Fatalf("Expected the decrypted token to be secret-token, got %s", plaintext)
+			}
+		})
+	}
+}
+
+func TestResolvePGPKey_invalid(t *testing.T) {
+	_, err := resolvePGPKey(context.Background(), "not a key")
+	if err == nil || !strings.Contains(err.Error(), "neither ASCII armored") {
+		t.Fatalf("Expected an invalid key error, got %v", err)
+	}
+}
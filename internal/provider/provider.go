@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,6 +21,7 @@ import (
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
 var _ provider.Provider = &RegistryToolsProvider{}
 var _ provider.ProviderWithFunctions = &RegistryToolsProvider{}
+var _ provider.ProviderWithEphemeralResources = &RegistryToolsProvider{}
 
 // RegistryToolsProvider defines the provider implementation.
 type RegistryToolsProvider struct {
@@ -32,6 +36,19 @@ type RegistryToolsProviderModel struct {
 	Hostname     types.String `tfsdk:"hostname"`
 	ClientID     types.String `tfsdk:"client_id"`
 	ClientSecret types.String `tfsdk:"client_secret"`
+
+	WarnTokenExpiryWithin types.String `tfsdk:"warn_token_expiry_within"`
+}
+
+// RegistryToolsProviderData is passed to resources and data sources when the
+// provider is configured. It embeds the API client, so it can be used as an
+// sdk.SDK directly.
+type RegistryToolsProviderData struct {
+	sdk.SDK
+
+	// WarnTokenExpiryWithin is the window before a token's expiry in which
+	// plans raise a warning. Zero disables the warning.
+	WarnTokenExpiryWithin time.Duration
 }
 
 func (p *RegistryToolsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -55,6 +72,10 @@ func (p *RegistryToolsProvider) Schema(ctx context.Context, req provider.SchemaR
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"warn_token_expiry_within": schema.StringAttribute{
+				MarkdownDescription: "Raise a plan warning for `rt_terraform_token` resources that expire within this duration, for example `168h`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -94,13 +115,35 @@ func (p *RegistryToolsProvider) Configure(ctx context.Context, req provider.Conf
 		return
 	}
 
+	warnTokenExpiryWithin, err := parseWarnTokenExpiryWithin(data.WarnTokenExpiryWithin)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("warn_token_expiry_within"), "Invalid Duration", fmt.Sprintf("The value must be a duration such as 168h: %v", err))
+		return
+	}
+
 	client, err := sdk.NewSDK(hostname, clientID, clientSecret)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to init", fmt.Sprintf("Could not initialize registry tools client: %v", err))
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	providerData := &RegistryToolsProviderData{
+		SDK:                   client,
+		WarnTokenExpiryWithin: warnTokenExpiryWithin,
+	}
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+}
+
+// parseWarnTokenExpiryWithin parses the warn_token_expiry_within provider
+// attribute. Unset values disable the warning.
+func parseWarnTokenExpiryWithin(value types.String) (time.Duration, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return 0, nil
+	}
+
+	return time.ParseDuration(value.ValueString())
 }
 
 func (p *RegistryToolsProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -112,8 +155,16 @@ func (p *RegistryToolsProvider) Resources(ctx context.Context) []func() resource
 	}
 }
 
+func (p *RegistryToolsProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewTerraformTokenEphemeralResource,
+	}
+}
+
 func (p *RegistryToolsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewTagPublisherDataSource,
+	}
 }
 
 func (p *RegistryToolsProvider) Functions(ctx context.Context) []func() function.Function {
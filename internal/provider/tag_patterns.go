@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.String = globPatternValidator{}
+
+// globPatternValidator validates that a string is a tag glob pattern, as used
+// by backfill_pattern.
+type globPatternValidator struct{}
+
+// globPattern returns a validator which ensures that the configured value is a
+// valid glob pattern.
+func globPattern() validator.String {
+	return globPatternValidator{}
+}
+
+func (v globPatternValidator) Description(ctx context.Context) string {
+	return "value must be a glob pattern such as v1.*"
+}
+
+func (v globPatternValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a glob pattern such as `v1.*`"
+}
+
+func (v globPatternValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := path.Match(req.ConfigValue.ValueString(), ""); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Pattern",
+			"The value must be a glob pattern such as v1.*, in which * and ? do not match /: "+err.Error(),
+		)
+	}
+}
+
+// matchTagPattern returns the tags matching the glob pattern, which must have
+// been validated.
+func matchTagPattern(pattern string, tags []string) []string {
+	matched := []string{}
+	for _, tag := range tags {
+		if ok, _ := path.Match(pattern, tag); ok {
+			matched = append(matched, tag)
+		}
+	}
+	return matched
+}
+
+// Prerelease policies of a tag publisher.
+const (
+	prereleasesAllow = "allow"
+	prereleasesDeny  = "deny"
+	prereleasesOnly  = "only"
+)
+
+// tagFilter selects the tags that a tag publisher publishes as module
+// versions, for both webhook deliveries and backfills.
+type tagFilter struct {
+	prefix      string
+	include     []string
+	exclude     []string
+	prereleases string
+}
+
+// version returns the module version of the tag, and whether the tag passes
+// the filter. Tags must start with the prefix and the rest must be a complete
+// semantic version, optionally prefixed with v, so tags such as v1 or v1.2
+// are never published.
+func (f tagFilter) version(tag string) (*semver.Version, bool) {
+	rest, ok := strings.CutPrefix(tag, f.prefix)
+	if !ok {
+		return nil, false
+	}
+
+	version, err := semver.StrictNewVersion(strings.TrimPrefix(rest, "v"))
+	if err != nil {
+		return nil, false
+	}
+
+	if len(f.include) > 0 && !matchesAnyTagPattern(tag, f.include) {
+		return nil, false
+	}
+
+	if matchesAnyTagPattern(tag, f.exclude) {
+		return nil, false
+	}
+
+	switch f.prereleases {
+	case prereleasesDeny:
+		return version, version.Prerelease() == ""
+	case prereleasesOnly:
+		return version, version.Prerelease() != ""
+	}
+
+	return version, true
+}
+
+// filter returns the tags passing the filter.
+func (f tagFilter) filter(tags []string) []string {
+	filtered := []string{}
+	for _, tag := range tags {
+		if _, ok := f.version(tag); ok {
+			filtered = append(filtered, tag)
+		}
+	}
+	return filtered
+}
+
+// matchesAnyTagPattern reports whether the tag matches one of the glob
+// patterns, which must have been validated.
+func matchesAnyTagPattern(tag string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isLiteralTagPattern reports whether the glob pattern only matches itself.
+func isLiteralTagPattern(pattern string) bool {
+	return !strings.ContainsAny(pattern, `*?[\`)
+}
+
+// listValueOrNull returns a null list for empty API values, which the API
+// uses for unset optional lists.
+func listValueOrNull(values []string) types.List {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType)
+	}
+
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// knownStringElements returns the known elements of a list of strings, or nil
+// for a null or unknown list. Elements are unknown when the list references
+// values which are not yet known, such as during validation.
+func knownStringElements(ctx context.Context, list types.List, diags *diag.Diagnostics) []string {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	var elements []types.String
+	diags.Append(list.ElementsAs(ctx, &elements, false)...)
+
+	values := make([]string, 0, len(elements))
+	for _, element := range elements {
+		if element.IsNull() || element.IsUnknown() {
+			continue
+		}
+		values = append(values, element.ValueString())
+	}
+
+	return values
+}
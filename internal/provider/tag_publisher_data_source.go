@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/registry-tools/rt-sdk"
+	"github.com/registry-tools/rt-sdk/generated/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TagPublisherDataSource{}
+var _ datasource.DataSourceWithConfigure = &TagPublisherDataSource{}
+
+func NewTagPublisherDataSource() datasource.DataSource {
+	return &TagPublisherDataSource{}
+}
+
+// TagPublisherDataSource defines the data source implementation.
+type TagPublisherDataSource struct {
+	client sdk.SDK
+}
+
+// TagPublisherDataSourceModel describes the data source data model.
+type TagPublisherDataSourceModel struct {
+	tagPublisherModel
+}
+
+func (d *TagPublisherDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag_publisher"
+}
+
+func (d *TagPublisherDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a tag publisher and its publishing status.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the tag publisher.",
+				Required:            true,
+			},
+			"vcs_connector_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"namespace_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"repo_identifier": schema.StringAttribute{
+				Computed: true,
+			},
+			"module_path": schema.StringAttribute{
+				Computed: true,
+			},
+			"module_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"module_provider": schema.StringAttribute{
+				Computed: true,
+			},
+			"tag_prefix": schema.StringAttribute{
+				Computed: true,
+			},
+			"include_patterns": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"exclude_patterns": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"prereleases": schema.StringAttribute{
+				Computed: true,
+			},
+			"backfill_pattern": schema.StringAttribute{
+				Computed: true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the tag publisher publishes tags, or is paused.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The publishing status of the tag publisher, such as `active`, `backfilling`, `paused` or `failed`.",
+				Computed:            true,
+			},
+			"webhook_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the webhook on the repository is installed and delivering events.",
+				Computed:            true,
+			},
+			"last_published_tag": schema.StringAttribute{
+				MarkdownDescription: "The last tag published as a module version.",
+				Computed:            true,
+			},
+			"last_published_at": schema.StringAttribute{
+				MarkdownDescription: "When the last tag was published.",
+				Computed:            true,
+			},
+			"last_error": schema.StringAttribute{
+				MarkdownDescription: "The last publishing error, if publishing failed.",
+				Computed:            true,
+			},
+			"published_version_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of module versions published from the repository.",
+				Computed:            true,
+			},
+			"webhook_secret_rotated_at": schema.StringAttribute{
+				MarkdownDescription: "When the webhook signing secret was last rotated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *TagPublisherDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdk.SDK)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected sdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TagPublisherDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TagPublisherDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagPublisher, err := d.client.Api().TagPublishers().ById(data.ID.ValueString()).GetAsTagPublishersGetResponse(ctx, nil)
+	if err != nil {
+		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
+		return
+	}
+
+	d.responseToModel(tagPublisher.GetData(), &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *TagPublisherDataSource) responseToModel(response models.TagPublisherable, model *TagPublisherDataSourceModel) {
+	model.tagPublisherModel.fromResponse(response)
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTagPublisherDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/tag_publishers/tp-1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, testTagPublisherJSON)
+	})
+	d := &TagPublisherDataSource{client: newTestSDK(t, mux)}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	state := tfsdk.State(config)
+	if diags := state.SetAttribute(ctx, path.Root("id"), "tp-1"); diags.HasError() {
+		t.Fatalf("Could not set the id: %v", diags)
+	}
+	config.Raw = state.Raw
+
+	resp := &datasource.ReadResponse{State: state}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+
+	var data TagPublisherDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+	}
+
+	// The data source reads the tag publisher exactly as the resource does
+	if !reflect.DeepEqual(data.tagPublisherModel, testTagPublisherModel) {
+		t.Fatalf("Expected %+v, got %+v", testTagPublisherModel, data.tagPublisherModel)
+	}
+}
@@ -3,37 +3,123 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdk "github.com/registry-tools/rt-sdk"
+	"github.com/registry-tools/rt-sdk/generated/api"
 	"github.com/registry-tools/rt-sdk/generated/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TagPublisherResource{}
 var _ resource.ResourceWithImportState = &TagPublisherResource{}
+var _ resource.ResourceWithModifyPlan = &TagPublisherResource{}
+var _ resource.ResourceWithValidateConfig = &TagPublisherResource{}
+
+// repoIdentifierFormat describes the repo_identifier accepted for a connector
+// type.
+type repoIdentifierFormat struct {
+	pattern *regexp.Regexp
+	example string
+}
+
+var (
+	modulePathPattern     = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9_.-]*(/[A-Za-z0-9_-][A-Za-z0-9_.-]*)*$`)
+	moduleNamePattern     = regexp.MustCompile(`^[0-9A-Za-z]([0-9A-Za-z_-]{0,62}[0-9A-Za-z])?$`)
+	moduleProviderPattern = regexp.MustCompile(`^[0-9a-z]{1,64}$`)
+)
+
+var repoIdentifierFormats = map[string]repoIdentifierFormat{
+	vcsProviderGitHub: {
+		pattern: regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`),
+		example: "owner/repository",
+	},
+	vcsProviderGitLab: {
+		pattern: regexp.MustCompile(`^[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)+$`),
+		example: "group/subgroup/project",
+	},
+	vcsProviderBitbucket: {
+		pattern: regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`),
+		example: "workspace/repository",
+	},
+	vcsProviderAzureDevOps: {
+		pattern: regexp.MustCompile(`^[^/]+/[^/]+$`),
+		example: "project/repository",
+	},
+	vcsProviderGit: {
+		// Segments may not start with a dot, which rules out . and ..
+		pattern: regexp.MustCompile(`^[A-Za-z0-9_~-][A-Za-z0-9_.~-]*(/[A-Za-z0-9_~-][A-Za-z0-9_.~-]*)*$`),
+		example: "path/to/repository",
+	},
+}
 
 func NewTagPublisherResource() resource.Resource {
-	return &TagPublisherResource{}
+	return &TagPublisherResource{
+		backfillPollInterval: defaultBackfillPollInterval,
+	}
 }
 
 // TagPublisherResource defines the resource implementation.
 type TagPublisherResource struct {
 	client sdk.SDK
+
+	// backfillPollInterval is how often the status is polled while waiting
+	// for the backfill.
+	backfillPollInterval time.Duration
 }
 
-// TagPublisherResourceModel describes the resource data model.
-type TagPublisherResourceModel struct {
+// tagPublisherModel describes the tag publisher attributes read from the API,
+// which are shared by the resource and data source data models.
+type tagPublisherModel struct {
 	ID              types.String `tfsdk:"id"`
 	VCSConnectorID  types.String `tfsdk:"vcs_connector_id"`
 	NamespaceID     types.String `tfsdk:"namespace_id"`
 	RepoIdentifier  types.String `tfsdk:"repo_identifier"`
+	ModulePath      types.String `tfsdk:"module_path"`
+	ModuleName      types.String `tfsdk:"module_name"`
+	ModuleProvider  types.String `tfsdk:"module_provider"`
+	TagPrefix       types.String `tfsdk:"tag_prefix"`
+	IncludePatterns types.List   `tfsdk:"include_patterns"`
+	ExcludePatterns types.List   `tfsdk:"exclude_patterns"`
+	Prereleases     types.String `tfsdk:"prereleases"`
 	BackfillPattern types.String `tfsdk:"backfill_pattern"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+
+	Status                 types.String `tfsdk:"status"`
+	WebhookActive          types.Bool   `tfsdk:"webhook_active"`
+	LastPublishedTag       types.String `tfsdk:"last_published_tag"`
+	LastPublishedAt        types.String `tfsdk:"last_published_at"`
+	LastError              types.String `tfsdk:"last_error"`
+	PublishedVersionCount  types.Int64  `tfsdk:"published_version_count"`
+	WebhookSecretRotatedAt types.String `tfsdk:"webhook_secret_rotated_at"`
+}
+
+// TagPublisherResourceModel describes the resource data model.
+type TagPublisherResourceModel struct {
+	tagPublisherModel
+
+	PreviewBackfill types.Bool `tfsdk:"preview_backfill"`
+	WaitForBackfill types.Bool `tfsdk:"wait_for_backfill"`
+
+	WebhookSecretRotationTriggers types.Map `tfsdk:"webhook_secret_rotation_triggers"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *TagPublisherResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -62,17 +148,133 @@ func (r *TagPublisherResource) Schema(ctx context.Context, req resource.SchemaRe
 				},
 			},
 			"repo_identifier": schema.StringAttribute{
-				Required: true,
+				MarkdownDescription: "The repository to publish tags from. The format depends on the type of the VCS connector: `owner/repository` for GitHub and GitHub Enterprise Server, `group/subgroup/project` for GitLab, `workspace/repository` for Bitbucket, `project/repository` for Azure DevOps and the path substituted into the clone URL for Git.",
+				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"backfill_pattern": schema.StringAttribute{
-				Optional: true,
+			"module_path": schema.StringAttribute{
+				MarkdownDescription: "The subdirectory of the repository containing the module, such as `modules/vpc`. Defaults to the root of the repository.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(modulePathPattern, "must be a relative path such as modules/vpc"),
+				},
+			},
+			"module_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the published module. Defaults to the name derived from the repository, such as `vpc` for `terraform-aws-vpc`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(moduleNamePattern, "must contain only letters, digits, dashes and underscores, and start and end with a letter or digit"),
+				},
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"module_provider": schema.StringAttribute{
+				MarkdownDescription: "The provider of the published module, such as `aws`. Defaults to the provider derived from the repository, such as `aws` for `terraform-aws-vpc`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(moduleProviderPattern, "must contain only lowercase letters and digits"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"tag_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only publish tags starting with this prefix, such as `vpc/` for tags like `vpc/v1.2.3`, and strip it to get the version. This lets several tag publishers share one repository and its webhook.",
+				Optional:            true,
+			},
+			"include_patterns": schema.ListAttribute{
+				MarkdownDescription: "Only publish tags matching one of these glob patterns, such as `v*`. Applies to webhook deliveries and backfills.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(globPattern()),
+				},
+			},
+			"exclude_patterns": schema.ListAttribute{
+				MarkdownDescription: "Never publish tags matching one of these glob patterns, such as `*-experiment*`. Applies to webhook deliveries and backfills.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(globPattern()),
 				},
 			},
+			"prereleases": schema.StringAttribute{
+				MarkdownDescription: "Whether to publish tags with a semantic version prerelease, such as `v1.2.0-rc.1`: `allow`, `deny` or `only`. Defaults to `allow`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(prereleasesAllow),
+				Validators: []validator.String{
+					stringvalidator.OneOf(prereleasesAllow, prereleasesDeny, prereleasesOnly),
+				},
+			},
+			"backfill_pattern": schema.StringAttribute{
+				MarkdownDescription: "A glob pattern, such as `v1.*`, matching the existing tags to publish. `*` and `?` do not match `/`.",
+				Optional:            true,
+				Validators: []validator.String{
+					globPattern(),
+				},
+			},
+			"preview_backfill": schema.BoolAttribute{
+				MarkdownDescription: "List the existing tags matching `backfill_pattern` in a warning when planning to create the tag publisher or to change `backfill_pattern`. The VCS connector must already exist.",
+				Optional:            true,
+			},
+			"wait_for_backfill": schema.BoolAttribute{
+				MarkdownDescription: "Wait on create until the tags matching `backfill_pattern` are published, so that other resources can use the module versions. Fails if the backfill fails.",
+				Optional:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether to publish tags. Set to `false` to pause publishing, for example during a repository migration, without removing the webhook or the published module versions. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"webhook_secret_rotation_triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that rotate the webhook signing secret, on the registry and on the repository, whenever they change. Removing them does not rotate the secret. For scheduled rotation, use the `id` of a `time_rotating` resource.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The publishing status of the tag publisher, such as `active`, `backfilling`, `paused` or `failed`.",
+				Computed:            true,
+			},
+			"webhook_active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the webhook on the repository is installed and delivering events.",
+				Computed:            true,
+			},
+			"last_published_tag": schema.StringAttribute{
+				MarkdownDescription: "The last tag published as a module version.",
+				Computed:            true,
+			},
+			"last_published_at": schema.StringAttribute{
+				MarkdownDescription: "When the last tag was published.",
+				Computed:            true,
+			},
+			"last_error": schema.StringAttribute{
+				MarkdownDescription: "The last publishing error, if publishing failed.",
+				Computed:            true,
+			},
+			"published_version_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of module versions published from the repository.",
+				Computed:            true,
+			},
+			"webhook_secret_rotated_at": schema.StringAttribute{
+				MarkdownDescription: "When the webhook signing secret was last rotated.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
 		},
 	}
 }
@@ -96,8 +298,273 @@ func (r *TagPublisherResource) Configure(ctx context.Context, req resource.Confi
 	r.client = client
 }
 
+func (r *TagPublisherResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TagPublisherResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A paused tag publisher does not backfill, so there is nothing to wait for
+	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() && !data.Enabled.ValueBool() && data.WaitForBackfill.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_for_backfill"),
+			"Invalid Attribute Combination",
+			"wait_for_backfill cannot be set when enabled is false, because a paused tag publisher does not backfill.",
+		)
+	}
+
+	if data.IncludePatterns.IsUnknown() || data.TagPrefix.IsUnknown() || data.Prereleases.IsUnknown() {
+		return
+	}
+
+	// Literal include patterns name single tags, which must be published.
+	// Unknown patterns are ignored, which only makes the filter less strict.
+	filter := data.tagFilter(ctx, &resp.Diagnostics)
+
+	var includePatterns []types.String
+	resp.Diagnostics.Append(data.IncludePatterns.ElementsAs(ctx, &includePatterns, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, pattern := range includePatterns {
+		if pattern.IsNull() || pattern.IsUnknown() || !isLiteralTagPattern(pattern.ValueString()) {
+			continue
+		}
+
+		if _, ok := filter.version(pattern.ValueString()); !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("include_patterns").AtListIndex(i),
+				"Tag Is Never Published",
+				fmt.Sprintf("The tag %q would never be published. Tags must start with tag_prefix, followed by a semantic version allowed by prereleases and not matching exclude_patterns.", pattern.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *TagPublisherResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate on destroy, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data TagPublisherResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The connector may not exist yet, in which case Create validates
+	if data.VCSConnectorID.IsUnknown() || data.RepoIdentifier.IsUnknown() {
+		return
+	}
+
+	// The repo identifier is only validated when it or the connector is new,
+	// so refreshing unchanged tag publishers does not depend on the API
+	var state TagPublisherResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if req.State.Raw.IsNull() || !data.VCSConnectorID.Equal(state.VCSConnectorID) || !data.RepoIdentifier.Equal(state.RepoIdentifier) {
+		vcsConnector, err := r.client.Api().VcsConnectors().ByVcsConnectorId(data.VCSConnectorID.ValueString()).GetAsVcsConnectorGetResponse(ctx, nil)
+		if err != nil {
+			// Create validates again, so API failures do not fail the plan
+			APIErrorsAsWarnings(err, path.Root("repo_identifier"), "Repository Identifier Not Validated", &resp.Diagnostics)
+		} else {
+			repoIdentifierDiagnostics(vcsConnector.GetData(), data.RepoIdentifier.ValueString(), &resp.Diagnostics)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.PreviewBackfill.ValueBool() || data.BackfillPattern.IsNull() || data.BackfillPattern.IsUnknown() {
+		return
+	}
+
+	// Tags are only backfilled on create or when the pattern changes
+	if !req.State.Raw.IsNull() && state.BackfillPattern.Equal(data.BackfillPattern) {
+		return
+	}
+
+	r.previewBackfill(ctx, data, &resp.Diagnostics)
+}
+
+// tagFilter returns the filter of the tags published as module versions.
+func (m TagPublisherResourceModel) tagFilter(ctx context.Context, diags *diag.Diagnostics) tagFilter {
+	filter := tagFilter{
+		prefix:      m.TagPrefix.ValueString(),
+		prereleases: m.Prereleases.ValueString(),
+	}
+
+	filter.include = knownStringElements(ctx, m.IncludePatterns, diags)
+	filter.exclude = knownStringElements(ctx, m.ExcludePatterns, diags)
+
+	return filter
+}
+
+// maxBackfillPreviewTags is the number of tags listed by the backfill preview.
+const maxBackfillPreviewTags = 20
+
+// previewBackfill lists the existing tags of the repository matching the
+// backfill pattern in a warning.
+func (r *TagPublisherResource) previewBackfill(ctx context.Context, data TagPublisherResourceModel, diags *diag.Diagnostics) {
+	response, err := r.client.Api().VcsConnectors().ByVcsConnectorId(data.VCSConnectorID.ValueString()).Repositories().ByRepositoryId(data.RepoIdentifier.ValueString()).Tags().GetAsTagsGetResponse(ctx, nil)
+	if err != nil {
+		APIErrorsAsWarnings(err, path.Root("preview_backfill"), "Backfill Preview Unavailable", diags)
+		return
+	}
+
+	tags := []string{}
+	for _, tag := range response.GetData() {
+		tags = append(tags, types.StringPointerValue(tag.GetName()).ValueString())
+	}
+
+	filter := data.tagFilter(ctx, diags)
+	if diags.HasError() {
+		return
+	}
+
+	diags.AddAttributeWarning(path.Root("backfill_pattern"), "Backfill Preview", backfillPreview(data.RepoIdentifier.ValueString(), data.BackfillPattern.ValueString(), filter, tags))
+}
+
+// backfillPreview describes the tags matching the backfill pattern which pass
+// the tag filter.
+func backfillPreview(repoIdentifier string, pattern string, filter tagFilter, tags []string) string {
+	matched := filter.filter(matchTagPattern(pattern, tags))
+	if len(matched) == 0 {
+		return fmt.Sprintf("No existing tags of %s match the backfill pattern %q.", repoIdentifier, pattern)
+	}
+
+	var preview strings.Builder
+	fmt.Fprintf(&preview, "%d existing tags of %s match the backfill pattern %q and will be published:\n", len(matched), repoIdentifier, pattern)
+	for i, tag := range matched {
+		if i == maxBackfillPreviewTags {
+			fmt.Fprintf(&preview, "\n  ... and %d more", len(matched)-maxBackfillPreviewTags)
+			break
+		}
+		fmt.Fprintf(&preview, "\n  %s", tag)
+	}
+
+	return preview.String()
+}
+
+// validateRepoIdentifier checks the repo identifier against the format of the
+// type of the VCS connector.
+func (r *TagPublisherResource) validateRepoIdentifier(ctx context.Context, vcsConnectorID string, repoIdentifier string, diags *diag.Diagnostics) {
+	vcsConnector, err := r.client.Api().VcsConnectors().ByVcsConnectorId(vcsConnectorID).GetAsVcsConnectorGetResponse(ctx, nil)
+	if err != nil {
+		APIErrorsAsDiagnostics(err, diags)
+		return
+	}
+
+	repoIdentifierDiagnostics(vcsConnector.GetData(), repoIdentifier, diags)
+}
+
+// repoIdentifierDiagnostics adds an error when the repo identifier does not
+// match the format of the connector type.
+func repoIdentifierDiagnostics(vcsConnector models.VCSConnectorable, repoIdentifier string, diags *diag.Diagnostics) {
+	provider := types.StringPointerValue(vcsConnector.GetProvider()).ValueString()
+	if example, ok := validRepoIdentifier(provider, repoIdentifier); !ok {
+		diags.AddAttributeError(
+			path.Root("repo_identifier"),
+			"Invalid Repository Identifier",
+			fmt.Sprintf("The repository identifier %q is not valid for a %s connector, expected the format %q.", repoIdentifier, provider, example),
+		)
+	}
+}
+
+// validRepoIdentifier returns an example of the expected format for the
+// connector type and whether the repo identifier is valid. Unknown
+// connector types are left to the API to validate.
+func validRepoIdentifier(provider string, repoIdentifier string) (string, bool) {
+	format, ok := repoIdentifierFormats[provider]
+	if !ok {
+		return "", true
+	}
+
+	return format.example, format.pattern.MatchString(repoIdentifier)
+}
+
 func (r *TagPublisherResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Tag Publishers cannot be updated. This is a bug in the provider.")
+	var data TagPublisherResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The repository, connector, namespace and module name and provider
+	// require replacement, so only the publishing settings are sent
+	updateTagPublisher := models.NewTagPublisher()
+
+	modulePath := ""
+	if !data.ModulePath.IsNull() {
+		modulePath = data.ModulePath.ValueString()
+	}
+	updateTagPublisher.SetModulePath(&modulePath)
+
+	tagPrefix := ""
+	if !data.TagPrefix.IsNull() {
+		tagPrefix = data.TagPrefix.ValueString()
+	}
+	updateTagPublisher.SetTagPrefix(&tagPrefix)
+
+	backfillPattern := ""
+	if !data.BackfillPattern.IsNull() {
+		backfillPattern = data.BackfillPattern.ValueString()
+	}
+	updateTagPublisher.SetBackfillPattern(&backfillPattern)
+
+	// Empty lists clear the filters
+	filter := data.tagFilter(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateTagPublisher.SetIncludePatterns(append([]string{}, filter.include...))
+	updateTagPublisher.SetExcludePatterns(append([]string{}, filter.exclude...))
+	updateTagPublisher.SetPrereleases(data.Prereleases.ValueStringPointer())
+	updateTagPublisher.SetEnabled(data.Enabled.ValueBoolPointer())
+
+	updateTagPublisherBody := api.NewTagPublishersPatchRequestBody()
+	updateTagPublisherBody.SetTagPublisher(updateTagPublisher)
+
+	tagPublisher, err := r.client.Api().TagPublishers().ById(data.ID.ValueString()).PatchAsTagPublishersPatchResponse(ctx, updateTagPublisherBody, nil)
+	if err != nil {
+		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
+		return
+	}
+
+	r.responseToModel(tagPublisher.GetData(), &data)
+
+	var state TagPublisherResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	// Removing the triggers stops rotation rather than rotating once more
+	if resp.Diagnostics.HasError() || data.WebhookSecretRotationTriggers.IsNull() || data.WebhookSecretRotationTriggers.Equal(state.WebhookSecretRotationTriggers) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	rotated, err := r.client.Api().TagPublishers().ById(data.ID.ValueString()).RotateWebhookSecret().PostAsRotateWebhookSecretPostResponse(ctx, nil)
+	if err != nil {
+		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
+
+		// Keep the previous triggers so that the rotation is planned again
+		data.WebhookSecretRotationTriggers = state.WebhookSecretRotationTriggers
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	r.responseToModel(rotated.GetData(), &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *TagPublisherResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -108,11 +575,29 @@ func (r *TagPublisherResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	r.validateRepoIdentifier(ctx, data.VCSConnectorID.ValueString(), data.RepoIdentifier.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	newTagPublisher := models.NewTagPublisher()
 	newTagPublisher.SetVcsConnectorId(data.VCSConnectorID.ValueStringPointer())
 	newTagPublisher.SetRepo(data.RepoIdentifier.ValueStringPointer())
+	newTagPublisher.SetModulePath(data.ModulePath.ValueStringPointer())
+	newTagPublisher.SetModuleName(data.ModuleName.ValueStringPointer())
+	newTagPublisher.SetModuleProvider(data.ModuleProvider.ValueStringPointer())
+	newTagPublisher.SetTagPrefix(data.TagPrefix.ValueStringPointer())
 	newTagPublisher.SetBackfillPattern(data.BackfillPattern.ValueStringPointer())
 
+	filter := data.tagFilter(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newTagPublisher.SetIncludePatterns(filter.include)
+	newTagPublisher.SetExcludePatterns(filter.exclude)
+	newTagPublisher.SetPrereleases(data.Prereleases.ValueStringPointer())
+	newTagPublisher.SetEnabled(data.Enabled.ValueBoolPointer())
+
 	tagPublisher, err := r.client.Api().Namespaces().ByNamespaceId(data.NamespaceID.ValueString()).TagPublishers().PostAsTagPublishersPostResponse(ctx, newTagPublisher, nil)
 	if err != nil {
 		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
@@ -122,13 +607,132 @@ func (r *TagPublisherResource) Create(ctx context.Context, req resource.CreateRe
 	r.responseToModel(tagPublisher.GetData(), &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.WaitForBackfill.ValueBool() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultBackfillTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// The tag publisher is in state already, so a failed backfill taints it
+	// rather than leaving it unmanaged
+	backfilled := r.waitForBackfill(waitCtx, data.ID.ValueString(), &resp.Diagnostics)
+	if backfilled == nil {
+		return
+	}
+
+	r.responseToModel(backfilled, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Tag publisher statuses reported by the API.
+const (
+	tagPublisherStatusActive      = "active"
+	tagPublisherStatusBackfilling = "backfilling"
+	tagPublisherStatusPaused      = "paused"
+	tagPublisherStatusFailed      = "failed"
+)
+
+// defaultBackfillTimeout is how long Create waits for the backfill when no
+// create timeout is configured.
+const defaultBackfillTimeout = 20 * time.Minute
+
+// defaultBackfillPollInterval is the default backfillPollInterval.
+const defaultBackfillPollInterval = 10 * time.Second
+
+// waitForBackfill polls the tag publisher until the backfill completes and
+// returns it, or returns nil once the backfill fails or the context ends.
+func (r *TagPublisherResource) waitForBackfill(ctx context.Context, id string, diags *diag.Diagnostics) models.TagPublisherable {
+	interval := r.backfillPollInterval
+	if interval <= 0 {
+		interval = defaultBackfillPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		response, err := r.client.Api().TagPublishers().ById(id).GetAsTagPublishersGetResponse(ctx, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				diags.AddError("Timed Out Waiting for Backfill", fmt.Sprintf("The backfill of tag publisher %s did not complete in time. Increase the create timeout, or unset wait_for_backfill.", id))
+				return nil
+			}
+			APIErrorsAsDiagnostics(err, diags)
+			return nil
+		}
+
+		tagPublisher := response.GetData()
+		status := types.StringPointerValue(tagPublisher.GetStatus()).ValueString()
+
+		// Only an active tag publisher has completed the backfill. Any other
+		// status, such as a pending one before the backfill starts, is polled.
+		switch status {
+		case tagPublisherStatusActive:
+			tflog.Info(ctx, "Tag publisher backfill completed", map[string]interface{}{
+				"id":                      id,
+				"published_version_count": types.Int64PointerValue(tagPublisher.GetPublishedVersionCount()).ValueInt64(),
+			})
+			return tagPublisher
+		case tagPublisherStatusPaused:
+			diags.AddError("Backfill Paused", fmt.Sprintf("Tag publisher %s was paused before the backfill completed. Set enabled to true to resume it.", id))
+			return nil
+		case tagPublisherStatusFailed:
+			diags.AddError("Backfill Failed", fmt.Sprintf("The backfill of tag publisher %s failed: %s", id, types.StringPointerValue(tagPublisher.GetLastError()).ValueString()))
+			return nil
+		default:
+			tflog.Info(ctx, "Waiting for tag publisher backfill", map[string]interface{}{
+				"id":                      id,
+				"status":                  status,
+				"published_version_count": types.Int64PointerValue(tagPublisher.GetPublishedVersionCount()).ValueInt64(),
+				"last_published_tag":      types.StringPointerValue(tagPublisher.GetLastPublishedTag()).ValueString(),
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError("Timed Out Waiting for Backfill", fmt.Sprintf("The backfill of tag publisher %s did not complete in time. Increase the create timeout, or unset wait_for_backfill.", id))
+			return nil
+		case <-ticker.C:
+		}
+	}
 }
 
 func (r *TagPublisherResource) responseToModel(response models.TagPublisherable, model *TagPublisherResourceModel) {
-	model.ID = types.StringPointerValue(response.GetId())
+	model.tagPublisherModel.fromResponse(response)
+}
+
+// fromResponse sets the attributes read from the API.
+func (m *tagPublisherModel) fromResponse(response models.TagPublisherable) {
+	m.ID = types.StringPointerValue(response.GetId())
+
+	m.NamespaceID = types.StringPointerValue(response.GetNamespaceId())
+	m.RepoIdentifier = types.StringPointerValue(response.GetRepo())
+	m.VCSConnectorID = types.StringPointerValue(response.GetVcsConnectorId())
+	m.ModulePath = stringValueOrNull(response.GetModulePath())
+	m.ModuleName = types.StringPointerValue(response.GetModuleName())
+	m.ModuleProvider = types.StringPointerValue(response.GetModuleProvider())
+	m.TagPrefix = stringValueOrNull(response.GetTagPrefix())
+	m.IncludePatterns = listValueOrNull(response.GetIncludePatterns())
+	m.ExcludePatterns = listValueOrNull(response.GetExcludePatterns())
+	m.Prereleases = types.StringPointerValue(response.GetPrereleases())
+	m.BackfillPattern = stringValueOrNull(response.GetBackfillPattern())
+	m.Enabled = types.BoolPointerValue(response.GetEnabled())
 
-	model.RepoIdentifier = types.StringPointerValue(response.GetRepo())
-	model.VCSConnectorID = types.StringPointerValue(response.GetVcsConnectorId())
+	m.Status = types.StringPointerValue(response.GetStatus())
+	m.WebhookActive = types.BoolPointerValue(response.GetWebhookActive())
+	m.LastPublishedTag = stringValueOrNull(response.GetLastPublishedTag())
+	m.LastPublishedAt = timeValueOrNull(response.GetLastPublishedAt())
+	m.LastError = stringValueOrNull(response.GetLastError())
+	m.PublishedVersionCount = types.Int64PointerValue(response.GetPublishedVersionCount())
+	m.WebhookSecretRotatedAt = timeValueOrNull(response.GetWebhookSecretRotatedAt())
 }
 
 func (r *TagPublisherResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -141,6 +745,10 @@ func (r *TagPublisherResource) Read(ctx context.Context, req resource.ReadReques
 
 	tagPublisher, err := r.client.Api().TagPublishers().ById(data.ID.ValueString()).GetAsTagPublishersGetResponse(ctx, nil)
 	if err != nil {
+		if IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
 		return
 	}
@@ -0,0 +1,500 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidRepoIdentifier(t *testing.T) {
+	cases := []struct {
+		provider       string
+		repoIdentifier string
+		valid          bool
+	}{
+		{vcsProviderGitHub, "registry-tools/terraform-rt-private-registry", true},
+		{vcsProviderGitHub, "registry-tools", false},
+		{vcsProviderGitHub, "group/subgroup/project", false},
+		{vcsProviderGitLab, "group/subgroup/project", true},
+		{vcsProviderGitLab, "project", false},
+		{vcsProviderBitbucket, "workspace/repository", true},
+		{vcsProviderBitbucket, "workspace/project/repository", false},
+		{vcsProviderAzureDevOps, "My Project/repository", true},
+		{vcsProviderAzureDevOps, "repository", false},
+		{vcsProviderGit, "path/to/repository.git", true},
+		{vcsProviderGit, "~user/repository", true},
+		{vcsProviderGit, "..", false},
+		{vcsProviderGit, "../../etc", false},
+		{vcsProviderGit, "a/../b", false},
+		{vcsProviderGit, "a/./b", false},
+		{vcsProviderGit, "a//b", false},
+		{"unknown", "anything", true},
+	}
+
+	for _, c := range cases {
+		if _, valid := validRepoIdentifier(c.provider, c.repoIdentifier); valid != c.valid {
+			t.Errorf("validRepoIdentifier(%q, %q) = %t, expected %t", c.provider, c.repoIdentifier, valid, c.valid)
+		}
+	}
+}
+
+// testTagPublisherJSON is a tag publisher returned by the API, which reads
+// as testTagPublisherModel.
+const testTagPublisherJSON = `{"data":{"id":"tp-1","vcs_connector_id":"connector-1","namespace_id":"ns-1","repo":"owner/repository","module_path":"modules/vpc","module_name":"vpc","module_provider":"aws","tag_prefix":"vpc/","include_patterns":["vpc/v1.*"],"prereleases":"deny","backfill_pattern":"v1.*","enabled":true,"status":"active","webhook_active":true,"last_published_tag":"v1.2.0","last_published_at":"2026-10-18T12:00:00Z","published_version_count":3}}`
+
+var testTagPublisherModel = tagPublisherModel{
+	ID:              types.StringValue("tp-1"),
+	VCSConnectorID:  types.StringValue("connector-1"),
+	NamespaceID:     types.StringValue("ns-1"),
+	RepoIdentifier:  types.StringValue("owner/repository"),
+	ModulePath:      types.StringValue("modules/vpc"),
+	ModuleName:      types.StringValue("vpc"),
+	ModuleProvider:  types.StringValue("aws"),
+	TagPrefix:       types.StringValue("vpc/"),
+	IncludePatterns: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("vpc/v1.*")}),
+	ExcludePatterns: types.ListNull(types.StringType),
+	Prereleases:     types.StringValue("deny"),
+	BackfillPattern: types.StringValue("v1.*"),
+	Enabled:         types.BoolValue(true),
+
+	Status:                 types.StringValue("active"),
+	WebhookActive:          types.BoolValue(true),
+	LastPublishedTag:       types.StringValue("v1.2.0"),
+	LastPublishedAt:        types.StringValue("2026-10-18T12:00:00Z"),
+	LastError:              types.StringNull(),
+	PublishedVersionCount:  types.Int64Value(3),
+	WebhookSecretRotatedAt: types.StringNull(),
+}
+
+func TestTagPublisherResourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/tag_publishers/tp-1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, testTagPublisherJSON)
+	})
+	mux.HandleFunc("GET /api/tag_publishers/tp-2", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"errors":[{"title":"Not found","detail":"The tag publisher was not found"}]}`)
+	})
+	r := &TagPublisherResource{client: newTestSDK(t, mux)}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	read := func(id string) *resource.ReadResponse {
+		// The state after an import, which only has the ID
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		setTestAttributes(t, &state, map[string]any{"id": id})
+
+		resp := &resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+		}
+		return resp
+	}
+
+	var data TagPublisherResourceModel
+	resp := read("tp-1")
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+	}
+
+	expected := TagPublisherResourceModel{
+		tagPublisherModel: testTagPublisherModel,
+
+		WebhookSecretRotationTriggers: types.MapNull(types.StringType),
+	}
+	expected.Timeouts = data.Timeouts
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, data)
+	}
+
+	if resp := read("tp-2"); !resp.State.Raw.IsNull() {
+		t.Fatal("Expected a tag publisher that was not found to be removed from state")
+	}
+}
+
+func TestBackfillPreview(t *testing.T) {
+	tags := []string{"v1.0.0", "v1.1.0", "v2.0.0", "modules/vpc/v1.0.0"}
+
+	preview := backfillPreview("owner/repository", "v1.*", tagFilter{}, tags)
+	if !strings.Contains(preview, "2 existing tags") || !strings.Contains(preview, "v1.1.0") || strings.Contains(preview, "v2.0.0") {
+		t.Errorf("Unexpected preview for v1.*: %s", preview)
+	}
+
+	if preview := backfillPreview("owner/repository", "v*", tagFilter{}, tags); strings.Contains(preview, "modules/vpc") {
+		t.Errorf("Expected * not to match /, got %s", preview)
+	}
+
+	if preview := backfillPreview("owner/repository", "v3.*", tagFilter{}, tags); !strings.HasPrefix(preview, "No existing tags") {
+		t.Errorf("Unexpected preview for v3.*: %s", preview)
+	}
+
+	many := []string{}
+	for i := 0; i < maxBackfillPreviewTags+5; i++ {
+		many = append(many, fmt.Sprintf("v1.%d.0", i))
+	}
+	if preview := backfillPreview("owner/repository", "v1.*", tagFilter{}, many); !strings.HasSuffix(preview, "... and 5 more") {
+		t.Errorf("Expected the preview to be truncated, got %s", preview)
+	}
+}
+
+func TestGlobPatternValidator(t *testing.T) {
+	cases := map[string]bool{
+		"v1.*":          true,
+		"v[0-9].*":      true,
+		"modules/vpc/*": true,
+		"v[1.*":         false,
+	}
+
+	for value, valid := range cases {
+		req := validator.StringRequest{
+			Path:        path.Root("backfill_pattern"),
+			ConfigValue: types.StringValue(value),
+		}
+		resp := &validator.StringResponse{}
+
+		globPattern().ValidateString(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("globPattern for %q: expected valid %t, got %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestTagPublisherResourceWaitForBackfill(t *testing.T) {
+	cases := map[string]struct {
+		statuses []string
+		timeout  time.Duration
+		wantErr  string
+	}{
+		"completes":           {statuses: []string{"backfilling", "backfilling", "active"}, timeout: time.Minute},
+		"fails":               {statuses: []string{"backfilling", "failed"}, timeout: time.Minute, wantErr: "Backfill Failed"},
+		"times out":           {statuses: []string{"backfilling"}, timeout: 50 * time.Millisecond, wantErr: "Timed Out Waiting for Backfill"},
+		"nothing to backfill": {statuses: []string{"active"}, timeout: time.Minute},
+		"pending":             {statuses: []string{"pending", "backfilling", "active"}, timeout: time.Minute},
+		"empty status":        {statuses: []string{"", "backfilling", "active"}, timeout: time.Minute},
+		"unknown status":      {statuses: []string{"queued", "active"}, timeout: time.Minute},
+		"never active":        {statuses: []string{"queued"}, timeout: 50 * time.Millisecond, wantErr: "Timed Out Waiting for Backfill"},
+		"paused":              {statuses: []string{"backfilling", "paused"}, timeout: time.Minute, wantErr: "Backfill Paused"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var polls atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/tag_publishers/tp-1", func(w http.ResponseWriter, r *http.Request) {
+				i := min(int(polls.Add(1))-1, len(c.statuses)-1)
+				writeJSON(w, http.StatusOK, fmt.Sprintf(`{"data":{"id":"tp-1","status":%q,"last_error":"bad tag"}}`, c.statuses[i]))
+			})
+			r := &TagPublisherResource{client: newTestSDK(t, mux), backfillPollInterval: time.Millisecond}
+
+			ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+			defer cancel()
+
+			var diags diag.Diagnostics
+			tagPublisher := r.waitForBackfill(ctx, "tp-1", &diags)
+
+			if c.wantErr == "" {
+				if diags.HasError() || tagPublisher == nil {
+					t.Fatalf("Unexpected error: %v", diags)
+				}
+				if n := int(polls.Load()); n != len(c.statuses) {
+					t.Fatalf("Expected %d polls, got %d", len(c.statuses), n)
+				}
+				return
+			}
+
+			if tagPublisher != nil || diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != c.wantErr {
+				t.Fatalf("Expected the error %q, got %v", c.wantErr, diags)
+			}
+		})
+	}
+}
+
+func TestModulePathPattern(t *testing.T) {
+	cases := map[string]bool{
+		"modules/vpc":        true,
+		"vpc":                true,
+		"modules/vpc.v2":     true,
+		"../vpc":             false,
+		"modules/../vpc":     false,
+		"/modules/vpc":       false,
+		"modules/vpc/":       false,
+		"modules//vpc":       false,
+		"./modules/vpc":      false,
+		"modules/.terraform": false,
+	}
+
+	for value, valid := range cases {
+		if modulePathPattern.MatchString(value) != valid {
+			t.Errorf("modulePathPattern for %q: expected valid %t", value, valid)
+		}
+	}
+}
+
+func TestTagFilter(t *testing.T) {
+	tags := []string{"v1.0.0", "v1.1.0-rc.1", "v1.1.0", "v2.0.0-experiment.1", "vpc/v1.0.0", "vpc/v1.1.0-rc.1", "release-1", "latest", "v1", "v1.2", "v1.2.3.4", "vv1.0.0"}
+
+	cases := map[string]struct {
+		filter   tagFilter
+		expected []string
+	}{
+		"semantic versions only": {
+			filter:   tagFilter{prereleases: prereleasesAllow},
+			expected: []string{"v1.0.0", "v1.1.0-rc.1", "v1.1.0", "v2.0.0-experiment.1"},
+		},
+		"deny prereleases": {
+			filter:   tagFilter{prereleases: prereleasesDeny},
+			expected: []string{"v1.0.0", "v1.1.0"},
+		},
+		"only prereleases": {
+			filter:   tagFilter{prereleases: prereleasesOnly},
+			expected: []string{"v1.1.0-rc.1", "v2.0.0-experiment.1"},
+		},
+		"include and exclude": {
+			filter:   tagFilter{include: []string{"v1.*", "v2.*"}, exclude: []string{"*-experiment*"}},
+			expected: []string{"v1.0.0", "v1.1.0-rc.1", "v1.1.0"},
+		},
+		"prefix": {
+			filter:   tagFilter{prefix: "vpc/", prereleases: prereleasesDeny},
+			expected: []string{"vpc/v1.0.0"},
+		},
+	}
+
+	for name, c := range cases {
+		if filtered := c.filter.filter(tags); !reflect.DeepEqual(filtered, c.expected) {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, filtered)
+		}
+	}
+}
+
+func TestTagPublisherResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	r := &TagPublisherResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	cases := map[string]struct {
+		include     []string
+		unknown     bool
+		prereleases string
+		paused      bool
+		wait        bool
+		wantError   bool
+	}{
+		"pattern":                     {include: []string{"v1.*"}, prereleases: prereleasesDeny},
+		"literal release":             {include: []string{"v1.2.0"}, prereleases: prereleasesDeny},
+		"literal denied prerelease":   {include: []string{"v1.2.0-rc.1"}, prereleases: prereleasesDeny, wantError: true},
+		"literal not semantic":        {include: []string{"latest"}, prereleases: prereleasesAllow, wantError: true},
+		"literal major only":          {include: []string{"v1"}, prereleases: prereleasesAllow, wantError: true},
+		"literal major minor only":    {include: []string{"v1.2"}, prereleases: prereleasesAllow, wantError: true},
+		"literal release only prerel": {include: []string{"v1.2.0"}, prereleases: prereleasesOnly, wantError: true},
+		"unknown pattern":             {include: []string{"v1.*"}, unknown: true, prereleases: prereleasesDeny},
+		"unknown and denied literal":  {include: []string{"v1.2.0-rc.1"}, unknown: true, prereleases: prereleasesDeny, wantError: true},
+		"wait while enabled":          {prereleases: prereleasesAllow, wait: true},
+		"wait while paused":           {prereleases: prereleasesAllow, paused: true, wait: true, wantError: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			state := tfsdk.State(config)
+			// An include pattern referencing a value which is not yet known
+			var include any = c.include
+			if c.unknown {
+				elements := []attr.Value{types.StringUnknown()}
+				for _, pattern := range c.include {
+					elements = append(elements, types.StringValue(pattern))
+				}
+				include = types.ListValueMust(types.StringType, elements)
+			}
+
+			setTestAttributes(t, &state, map[string]any{
+				"include_patterns":  include,
+				"prereleases":       c.prereleases,
+				"enabled":           !c.paused,
+				"wait_for_backfill": c.wait,
+			})
+			config.Raw = state.Raw
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != c.wantError {
+				t.Fatalf("Expected error %t, got %v", c.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestTagPublisherResourceUpdateRotatesWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+
+	var rotations atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /api/tag_publishers/tp-1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"data":{"id":"tp-1","enabled":true,"prereleases":"allow","status":"active"}}`)
+	})
+	mux.HandleFunc("POST /api/tag_publishers/tp-1/rotate_webhook_secret", func(w http.ResponseWriter, r *http.Request) {
+		rotations.Add(1)
+		writeJSON(w, http.StatusOK, `{"data":{"id":"tp-1","enabled":true,"prereleases":"allow","status":"active","webhook_secret_rotated_at":"2026-10-18T12:00:00Z"}}`)
+	})
+	r := &TagPublisherResource{client: newTestSDK(t, mux)}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	newState := func(t *testing.T, triggers map[string]string) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		setTestAttributes(t, &state, map[string]any{
+			"id":                               "tp-1",
+			"enabled":                          true,
+			"prereleases":                      prereleasesAllow,
+			"webhook_secret_rotation_triggers": triggers,
+		})
+		return state
+	}
+
+	cases := map[string]struct {
+		prior      map[string]string
+		planned    map[string]string
+		wantRotate bool
+	}{
+		"unchanged": {prior: map[string]string{"quarter": "q3"}, planned: map[string]string{"quarter": "q3"}},
+		"unset":     {},
+		"changed":   {prior: map[string]string{"quarter": "q3"}, planned: map[string]string{"quarter": "q4"}, wantRotate: true},
+		"added":     {planned: map[string]string{"quarter": "q4"}, wantRotate: true},
+		"removed":   {prior: map[string]string{"quarter": "q3"}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			rotations.Store(0)
+
+			plan := newState(t, c.planned)
+			resp := &resource.UpdateResponse{State: newState(t, c.prior)}
+			r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(plan), State: newState(t, c.prior)}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+			}
+
+			if rotated := rotations.Load() == 1; rotated != c.wantRotate {
+				t.Fatalf("Expected rotation %t, got %d rotations", c.wantRotate, rotations.Load())
+			}
+
+			var data TagPublisherResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if c.wantRotate && data.WebhookSecretRotatedAt.ValueString() != "2026-10-18T12:00:00Z" {
+				t.Fatalf("Expected webhook_secret_rotated_at to be set, got %s", data.WebhookSecretRotatedAt)
+			}
+		})
+	}
+}
+
+func TestTagPublisherResourceModifyPlanValidatesRepoIdentifier(t *testing.T) {
+	ctx := context.Background()
+
+	cases := map[string]struct {
+		prior           string
+		planned         string
+		connectorStatus int
+		wantGets        int32
+		wantError       bool
+		wantWarning     bool
+	}{
+		"create":                  {planned: "owner/repository", connectorStatus: http.StatusOK, wantGets: 1},
+		"create invalid":          {planned: "repository", connectorStatus: http.StatusOK, wantGets: 1, wantError: true},
+		"create with API failure": {planned: "owner/repository", connectorStatus: http.StatusInternalServerError, wantGets: 1, wantWarning: true},
+		"unchanged":               {prior: "owner/repository", planned: "owner/repository", connectorStatus: http.StatusInternalServerError},
+		"changed":                 {prior: "owner/repository", planned: "owner/other", connectorStatus: http.StatusOK, wantGets: 1},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gets atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/vcs_connectors/connector-1", func(w http.ResponseWriter, r *http.Request) {
+				gets.Add(1)
+				if c.connectorStatus != http.StatusOK {
+					writeJSON(w, c.connectorStatus, `{"errors":[{"title":"Unavailable","detail":"The API is unavailable"}]}`)
+					return
+				}
+				writeJSON(w, http.StatusOK, `{"data":{"id":"connector-1","provider":"github"}}`)
+			})
+			r := &TagPublisherResource{client: newTestSDK(t, mux)}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			newState := func(repoIdentifier string) tfsdk.State {
+				state := tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				}
+				if repoIdentifier == "" {
+					return state
+				}
+				setTestAttributes(t, &state, map[string]any{
+					"id":               "tp-1",
+					"vcs_connector_id": "connector-1",
+					"namespace_id":     "ns-1",
+					"repo_identifier":  repoIdentifier,
+				})
+				return state
+			}
+
+			plan := tfsdk.Plan(newState(c.planned))
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: newState(c.prior), Plan: plan}, resp)
+
+			if n := gets.Load(); n != c.wantGets {
+				t.Fatalf("Expected %d connector requests, got %d", c.wantGets, n)
+			}
+			if resp.Diagnostics.HasError() != c.wantError {
+				t.Fatalf("Expected error %t, got %v", c.wantError, resp.Diagnostics)
+			}
+			if warned := resp.Diagnostics.WarningsCount() > 0; warned != c.wantWarning {
+				t.Fatalf("Expected warning %t, got %v", c.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// setTestAttributes sets the attributes of the state, failing the test when
+// one cannot be set.
+func setTestAttributes(t *testing.T, state *tfsdk.State, attributes map[string]any) {
+	t.Helper()
+
+	for name, value := range attributes {
+		if diags := state.SetAttribute(context.Background(), path.Root(name), value); diags.HasError() {
+			t.Fatalf("Could not set %s: %v", name, diags)
+		}
+	}
+}
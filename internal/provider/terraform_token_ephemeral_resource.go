@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/registry-tools/rt-sdk"
+	"github.com/registry-tools/rt-sdk/generated/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &TerraformTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &TerraformTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &TerraformTokenEphemeralResource{}
+
+func NewTerraformTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &TerraformTokenEphemeralResource{}
+}
+
+// TerraformTokenEphemeralResource defines the ephemeral resource implementation.
+// The token is created on Open and revoked on Close, so it is never persisted
+// in plan or state.
+type TerraformTokenEphemeralResource struct {
+	client sdk.SDK
+}
+
+// TerraformTokenEphemeralResourceModel describes the ephemeral resource data model.
+type TerraformTokenEphemeralResourceModel struct {
+	Role        types.String `tfsdk:"role"`
+	Description types.String `tfsdk:"description"`
+	ID          types.String `tfsdk:"id"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	ExpiresIn   types.String `tfsdk:"expires_in"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	Token       types.String `tfsdk:"token"`
+}
+
+func (r *TerraformTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_terraform_token"
+}
+
+func (r *TerraformTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				Required: true,
+			},
+			"namespace_id": schema.StringAttribute{
+				Required: true,
+			},
+			"expires_in": schema.StringAttribute{
+				Required: true,
+			},
+			"expires_at": schema.StringAttribute{
+				Computed: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (r *TerraformTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdk.SDK)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected sdk.SDK, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TerraformTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data TerraformTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	description := "Managed by terraform-provider-rt"
+	if !data.Description.IsNull() {
+		description = data.Description.ValueString()
+	}
+
+	sa, token := createServiceAccountToken(ctx, r.client, data.NamespaceID.ValueString(), data.Role.ValueString(), description, data.ExpiresIn.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	privateData := TerraformTokenPrivateData{
+		ServiceAccountID:      *sa.GetId(),
+		AuthenticationTokenID: *token.GetId(),
+	}
+
+	err := setTerraformTokenPrivateData(ctx, resp.Private, privateData)
+	if err != nil {
+		resp.Diagnostics.AddError("Internal error storing private data for this ephemeral resource", err.Error())
+
+		// Close is never called without private data, so revoke the token now
+		deleteServiceAccountToken(ctx, r.client, privateData, &resp.Diagnostics)
+		return
+	}
+
+	r.responseToModel(sa, token, &data)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *TerraformTokenEphemeralResource) responseToModel(responseSA models.ServiceAccountable, responseToken models.AuthenticationTokenable, model *TerraformTokenEphemeralResourceModel) {
+	model.ID = types.StringPointerValue(responseToken.GetId())
+	model.Description = types.StringPointerValue(responseToken.GetDescription())
+	model.Role = types.StringPointerValue(responseSA.GetRole())
+	model.ExpiresAt = types.StringPointerValue(responseToken.GetExpiresAt())
+	model.Token = types.StringPointerValue(responseToken.GetToken())
+}
+
+func (r *TerraformTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateData, err := getTerraformTokenPrivateData(ctx, req.Private)
+	if err != nil {
+		resp.Diagnostics.AddError("Internal error fetching private data for this ephemeral resource", err.Error())
+		return
+	}
+
+	if privateData.AuthenticationTokenID == "" {
+		// Nothing was created by Open
+		return
+	}
+
+	deleteServiceAccountToken(ctx, r.client, privateData, &resp.Diagnostics)
+}
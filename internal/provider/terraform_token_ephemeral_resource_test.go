@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdk "github.com/registry-tools/rt-sdk"
+)
+
+// testProvider is the provider configured with a fake API client. Ephemeral
+// resource private data can only be created by the framework, so the
+// ephemeral resource is tested through the provider server.
+type testProvider struct {
+	RegistryToolsProvider
+	client sdk.SDK
+}
+
+func (p *testProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	providerData := &RegistryToolsProviderData{SDK: p.client}
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+}
+
+func newTestProviderServer(t *testing.T, handler http.Handler) tfprotov6.ProviderServer {
+	t.Helper()
+
+	server := providerserver.NewProtocol6(&testProvider{client: newTestSDK(t, handler)})()
+
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("Could not configure the provider: %v %v", err, resp.Diagnostics)
+	}
+
+	return server
+}
+
+func TestTerraformTokenEphemeralResourceOpenClose(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeTokenAPI{tokenStatus: http.StatusCreated, deleteStatus: http.StatusNoContent}
+	server := newTestProviderServer(t, fake.handler())
+
+	var schemaResp ephemeral.SchemaResponse
+	NewTerraformTokenEphemeralResource().Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	config, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"role":         tftypes.NewValue(tftypes.String, "provisioner"),
+		"namespace_id": tftypes.NewValue(tftypes.String, "ns-1"),
+		"expires_in":   tftypes.NewValue(tftypes.String, "5m"),
+		"expires_at":   tftypes.NewValue(tftypes.String, nil),
+		"description":  tftypes.NewValue(tftypes.String, nil),
+		"id":           tftypes.NewValue(tftypes.String, nil),
+		"token":        tftypes.NewValue(tftypes.String, nil),
+	}))
+	if err != nil {
+		t.Fatalf("Could not encode the config: %v", err)
+	}
+
+	openResp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "rt_terraform_token",
+		Config:   &config,
+	})
+	if err != nil || len(openResp.Diagnostics) > 0 {
+		t.Fatalf("Unexpected error: %v %v", err, openResp.Diagnostics)
+	}
+
+	result, err := openResp.Result.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("Could not decode the result: %v", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := result.As(&attributes); err != nil {
+		t.Fatalf("Could not decode the result: %v", err)
+	}
+
+	var id, token string
+	_ = attributes["id"].As(&id)
+	_ = attributes["token"].As(&token)
+	if id != "token-1" || token != "secret" {
+		t.Fatalf("Expected token-1 with its token, got %q and %q", id, token)
+	}
+
+	// The private data round trips through the framework to Close
+	closeResp, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: "rt_terraform_token",
+		Private:  openResp.Private,
+	})
+	if err != nil || len(closeResp.Diagnostics) > 0 {
+		t.Fatalf("Unexpected error: %v %v", err, closeResp.Diagnostics)
+	}
+
+	if n := fake.tokensDel.Load(); n != 1 {
+		t.Fatalf("Expected the token to be deleted once, got %d", n)
+	}
+	if n := fake.serviceAccountsDel.Load(); n != 1 {
+		t.Fatalf("Expected the service account to be deleted once, got %d", n)
+	}
+}
+
+func TestTerraformTokenEphemeralResourceClose_withoutPrivateData(t *testing.T) {
+	ctx := context.Background()
+	server := newTestProviderServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	closeResp, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: "rt_terraform_token",
+	})
+	if err != nil || len(closeResp.Diagnostics) > 0 {
+		t.Fatalf("Unexpected error: %v %v", err, closeResp.Diagnostics)
+	}
+}
+
+// testPrivateData stores private data keys in memory.
+type testPrivateData map[string][]byte
+
+func (d testPrivateData) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return d[key], nil
+}
+
+func (d testPrivateData) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	d[key] = value
+	return nil
+}
+
+func TestTerraformTokenPrivateData(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateData{}
+
+	empty, err := getTerraformTokenPrivateData(ctx, private)
+	if err != nil || empty != (TerraformTokenPrivateData{}) {
+		t.Fatalf("Expected empty private data, got %+v and %v", empty, err)
+	}
+
+	want := TerraformTokenPrivateData{ServiceAccountID: "sa-1", AuthenticationTokenID: "token-1"}
+	if err := setTerraformTokenPrivateData(ctx, private, want); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := getTerraformTokenPrivateData(ctx, private)
+	if err != nil || got != want {
+		t.Fatalf("Expected %+v, got %+v and %v", want, got, err)
+	}
+}
@@ -5,20 +5,28 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdk "github.com/registry-tools/rt-sdk"
 	"github.com/registry-tools/rt-sdk/generated/models"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TerraformTokenResource{}
+var _ resource.ResourceWithImportState = &TerraformTokenResource{}
+var _ resource.ResourceWithUpgradeState = &TerraformTokenResource{}
+var _ resource.ResourceWithModifyPlan = &TerraformTokenResource{}
 
 func NewTerraformTokenResource() resource.Resource {
 	return &TerraformTokenResource{}
@@ -26,7 +34,8 @@ func NewTerraformTokenResource() resource.Resource {
 
 // TerraformTokenResource defines the resource implementation.
 type TerraformTokenResource struct {
-	client sdk.SDK
+	client                sdk.SDK
+	warnTokenExpiryWithin time.Duration
 }
 
 // TerraformTokenResourceModel describes the resource data model.
@@ -38,6 +47,25 @@ type TerraformTokenResourceModel struct {
 	ExpiresIn   types.String `tfsdk:"expires_in"`
 	ExpiresAt   types.String `tfsdk:"expires_at"`
 	Token       types.String `tfsdk:"token"`
+
+	ServiceAccountID   types.String `tfsdk:"service_account_id"`
+	ServiceAccountName types.String `tfsdk:"service_account_name"`
+
+	PGPKey         types.String `tfsdk:"pgp_key"`
+	EncryptedToken types.String `tfsdk:"encrypted_token"`
+	KeyFingerprint types.String `tfsdk:"key_fingerprint"`
+}
+
+// terraformTokenResourceModelV0 describes the schema version 0 data model,
+// before the service account was exposed as attributes.
+type terraformTokenResourceModelV0 struct {
+	Role        types.String `tfsdk:"role"`
+	Description types.String `tfsdk:"description"`
+	ID          types.String `tfsdk:"id"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	ExpiresIn   types.String `tfsdk:"expires_in"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	Token       types.String `tfsdk:"token"`
 }
 
 type TerraformTokenPrivateData struct {
@@ -49,12 +77,17 @@ type PrivateData interface {
 	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
 }
 
+type PrivateDataWriter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
 func (r *TerraformTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_terraform_token"
 }
 
 func (r *TerraformTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"role": schema.StringAttribute{
 				Required: true,
@@ -71,7 +104,14 @@ func (r *TerraformTokenResource) Schema(ctx context.Context, req resource.Schema
 			"expires_in": schema.StringAttribute{
 				Required: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							// Imported tokens have no expires_in, so the configured value is adopted in place.
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing the expiration requires a new token, unless the token was imported.",
+						"Changing the expiration requires a new token, unless the token was imported.",
+					),
 				},
 			},
 			"expires_at": schema.StringAttribute{
@@ -92,8 +132,84 @@ func (r *TerraformTokenResource) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 			"token": schema.StringAttribute{
-				Computed:  true,
-				Sensitive: true,
+				MarkdownDescription: "The token value. Empty when `pgp_key` is set.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"pgp_key": schema.StringAttribute{
+				MarkdownDescription: "Either an ASCII armored or base64 encoded PGP public key, or a keybase username in the form `keybase:some_person_that_exists`. When set, the token is stored encrypted in `encrypted_token` instead of in plain text in `token`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"encrypted_token": schema.StringAttribute{
+				MarkdownDescription: "The token value, encrypted with `pgp_key` and base64 encoded. Decrypt it with `terraform output -raw encrypted_token | base64 --decode | gpg --decrypt`.",
+				Computed:            true,
+			},
+			"key_fingerprint": schema.StringAttribute{
+				MarkdownDescription: "The fingerprint of the PGP key used to encrypt the token.",
+				Computed:            true,
+			},
+			"service_account_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the managed service account that owns the token.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_account_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the managed service account that owns the token.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TerraformTokenResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"role":         schema.StringAttribute{Required: true},
+					"namespace_id": schema.StringAttribute{Required: true},
+					"expires_in":   schema.StringAttribute{Required: true},
+					"expires_at":   schema.StringAttribute{Computed: true},
+					"description":  schema.StringAttribute{Optional: true, Computed: true},
+					"id":           schema.StringAttribute{Computed: true},
+					"token":        schema.StringAttribute{Computed: true, Sensitive: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior terraformTokenResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// Private state is not available while upgrading, so the
+				// service account attributes are backfilled from it by the
+				// Read that follows.
+				upgraded := TerraformTokenResourceModel{
+					Role:               prior.Role,
+					Description:        prior.Description,
+					ID:                 prior.ID,
+					NamespaceID:        prior.NamespaceID,
+					ExpiresIn:          prior.ExpiresIn,
+					ExpiresAt:          prior.ExpiresAt,
+					Token:              prior.Token,
+					ServiceAccountID:   types.StringNull(),
+					ServiceAccountName: types.StringNull(),
+					PGPKey:             types.StringNull(),
+					EncryptedToken:     types.StringNull(),
+					KeyFingerprint:     types.StringNull(),
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
 			},
 		},
 	}
@@ -116,59 +232,105 @@ func (r *TerraformTokenResource) Configure(ctx context.Context, req resource.Con
 	}
 
 	r.client = client
-}
 
-func (r *TerraformTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data TerraformTokenResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if providerData, ok := req.ProviderData.(*RegistryToolsProviderData); ok {
+		r.warnTokenExpiryWithin = providerData.WarnTokenExpiryWithin
+	}
+}
 
-	if resp.Diagnostics.HasError() {
+// ModifyPlan plans the replacement of tokens that have already expired, and
+// warns about tokens that expire soon. Revoked tokens are removed from state
+// by Read, so they are planned for creation during the refresh.
+func (r *TerraformTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
 		return
 	}
 
-	newSA := models.NewServiceAccount()
-	name := fmt.Sprintf("managed-sa %s token", data.Role.ValueString())
-	newSA.SetName(&name)
-	newSA.SetRole(data.Role.ValueStringPointer())
+	var state TerraformTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || state.ExpiresAt.ValueString() == "" {
+		return
+	}
 
-	sa, err := r.client.Api().Namespaces().ByNamespaceId(data.NamespaceID.ValueString()).ServiceAccounts().PostAsServiceAccountsPostResponse(ctx, newSA, nil)
+	expiresAt, err := time.Parse(time.RFC3339, state.ExpiresAt.ValueString())
 	if err != nil {
-		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
+		tflog.Warn(ctx, "Could not parse token expiry", map[string]interface{}{"expires_at": state.ExpiresAt.ValueString(), "error": err.Error()})
 		return
 	}
 
-	saID := sa.GetData().GetId()
+	remaining := time.Until(expiresAt)
+	if remaining <= 0 {
+		for _, attr := range []string{"id", "expires_at", "token", "encrypted_token", "key_fingerprint", "service_account_id", "service_account_name"} {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attr), types.StringUnknown())...)
+		}
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at"))
+		return
+	}
 
-	newAuthToken := models.NewAuthenticationToken()
-	newAuthToken.SetDescription(data.Description.ValueStringPointer())
+	if r.warnTokenExpiryWithin > 0 && remaining < r.warnTokenExpiryWithin {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("expires_at"),
+			"Token expires soon",
+			fmt.Sprintf("The token %s expires at %s, within the configured warn_token_expiry_within of %s.", state.ID.ValueString(), state.ExpiresAt.ValueString(), r.warnTokenExpiryWithin),
+		)
+	}
+}
+
+func (r *TerraformTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TerraformTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
-	if data.ExpiresIn.ValueString() != "never" {
-		newAuthToken.SetExpiresAfter(data.ExpiresIn.ValueStringPointer())
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	token, err := r.client.Api().ServiceAccounts().ByServiceAccountId(*saID).AuthenticationTokens().PostAsAuthenticationTokensPostResponse(ctx, newAuthToken, nil)
-	if err != nil {
-		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
+	// Resolve the key before creating anything, so a bad key fails early
+	var pgpEntity *openpgp.Entity
+	if !data.PGPKey.IsNull() {
+		var err error
+		pgpEntity, err = resolvePGPKey(ctx, data.PGPKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("pgp_key"), "Invalid PGP key", err.Error())
+			return
+		}
+	}
+
+	sa, token := createServiceAccountToken(ctx, r.client, data.NamespaceID.ValueString(), data.Role.ValueString(), data.Description.ValueString(), data.ExpiresIn.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	privateData := TerraformTokenPrivateData{
-		ServiceAccountID:      *saID,
-		AuthenticationTokenID: *token.GetData().GetId(),
+		ServiceAccountID:      *sa.GetId(),
+		AuthenticationTokenID: *token.GetId(),
 	}
 
-	privateDataBytes, err := json.Marshal(privateData)
+	err := setTerraformTokenPrivateData(ctx, resp.Private, privateData)
 	if err != nil {
 		resp.Diagnostics.AddError("Internal error storing private data for this resource", err.Error())
 		return
 	}
 
-	resp.Diagnostics.Append(resp.Private.SetKey(ctx, "sa_token_data", privateDataBytes)...)
-	if resp.Diagnostics.HasError() {
-		return
+	r.responseToModel(sa, token, &data)
+
+	data.EncryptedToken = types.StringNull()
+	data.KeyFingerprint = types.StringNull()
+	if pgpEntity != nil {
+		encrypted, fingerprint, err := encryptWithPGPKey(pgpEntity, types.StringPointerValue(token.GetToken()).ValueString())
+		if err != nil {
+			// The token exists but cannot be stored safely, so revoke it again
+			deleteServiceAccountToken(ctx, r.client, privateData, &resp.Diagnostics)
+			resp.Diagnostics.AddAttributeError(path.Root("pgp_key"), "Token encryption failed", err.Error())
+			return
+		}
+
+		data.Token = types.StringNull()
+		data.EncryptedToken = types.StringValue(encrypted)
+		data.KeyFingerprint = types.StringValue(fingerprint)
 	}
 
-	r.responseToModel(sa.GetData(), token.GetData(), &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -177,19 +339,93 @@ func (r *TerraformTokenResource) responseToModel(responseSA models.ServiceAccoun
 	model.Description = types.StringPointerValue(responseToken.GetDescription())
 	model.Role = types.StringPointerValue(responseSA.GetRole())
 	model.ExpiresAt = types.StringPointerValue(responseToken.GetExpiresAt())
+	model.ServiceAccountID = types.StringPointerValue(responseSA.GetId())
+	model.ServiceAccountName = types.StringPointerValue(responseSA.GetName())
+
+	if namespaceID := responseSA.GetNamespaceId(); namespaceID != nil {
+		model.NamespaceID = types.StringValue(*namespaceID)
+	}
 
-	if token := responseToken.GetToken(); token != nil {
+	// Never store the plain text token when it is to be encrypted
+	if token := responseToken.GetToken(); token != nil && model.PGPKey.IsNull() {
 		model.Token = types.StringValue(*token)
 	}
 }
 
-func (r *TerraformTokenResource) privateData(ctx context.Context, private PrivateData) (TerraformTokenPrivateData, error) {
+// createServiceAccountToken creates a managed service account in the namespace
+// and issues an authentication token for it. If the token cannot be issued,
+// the service account is deleted again so that it is not orphaned.
+func createServiceAccountToken(ctx context.Context, client sdk.SDK, namespaceID, role, description, expiresIn string, diags *diag.Diagnostics) (models.ServiceAccountable, models.AuthenticationTokenable) {
+	newSA := models.NewServiceAccount()
+	name := fmt.Sprintf("managed-sa %s token", role)
+	newSA.SetName(&name)
+	newSA.SetRole(&role)
+
+	sa, err := client.Api().Namespaces().ByNamespaceId(namespaceID).ServiceAccounts().PostAsServiceAccountsPostResponse(ctx, newSA, nil)
+	if err != nil {
+		APIErrorsAsDiagnostics(err, diags)
+		return nil, nil
+	}
+
+	saID := sa.GetData().GetId()
+
+	newAuthToken := models.NewAuthenticationToken()
+	newAuthToken.SetDescription(&description)
+
+	if expiresIn != "never" {
+		newAuthToken.SetExpiresAfter(&expiresIn)
+	}
+
+	token, err := client.Api().ServiceAccounts().ByServiceAccountId(*saID).AuthenticationTokens().PostAsAuthenticationTokensPostResponse(ctx, newAuthToken, nil)
+	if err != nil {
+		APIErrorsAsDiagnostics(err, diags)
+
+		// Roll back the service account, nothing will be saved to state
+		rollbackErr := client.Api().ServiceAccounts().ByServiceAccountId(*saID).Delete(ctx, nil)
+		if rollbackErr != nil && !IsNotFoundError(rollbackErr) {
+			diags.AddWarning("Service account could not be rolled back", fmt.Sprintf("The authentication token could not be created and the service account %s created for it could not be deleted. Delete it manually: %v", *saID, rollbackErr))
+		}
+		return nil, nil
+	}
+
+	return sa.GetData(), token.GetData()
+}
+
+// deleteServiceAccountToken revokes the authentication token and then makes a
+// best attempt to delete its service account. It returns false if the token
+// could not be revoked.
+func deleteServiceAccountToken(ctx context.Context, client sdk.SDK, privateData TerraformTokenPrivateData, diags *diag.Diagnostics) bool {
+	err := client.Api().AuthenticationTokens().ByTokenId(privateData.AuthenticationTokenID).Delete(ctx, nil)
+	if err != nil && !IsNotFoundError(err) {
+		APIErrorsAsDiagnostics(err, diags)
+		return false
+	}
+
+	// Best attempt to delete the service account
+	err = client.Api().ServiceAccounts().ByServiceAccountId(privateData.ServiceAccountID).Delete(ctx, nil)
+	if err != nil && !IsNotFoundError(err) {
+		// Warn about the service account not being deleted
+		if apiErrors, ok := err.(*models.Errors); ok {
+			for _, err := range apiErrors.GetErrors() {
+				diags.AddWarning("Service account resource could not be deleted", fmt.Sprintf("The autenticaton token was deleted, but the associated service account could not be deleted: %s: %s", *err.GetTitle(), *err.GetDetail()))
+			}
+		}
+	}
+
+	return true
+}
+
+func getTerraformTokenPrivateData(ctx context.Context, private PrivateData) (TerraformTokenPrivateData, error) {
 	var privateData TerraformTokenPrivateData
 	privateDataBytes, diags := private.GetKey(ctx, "sa_token_data")
 	if diags.HasError() {
 		return privateData, errors.New("error getting private state")
 	}
 
+	if len(privateDataBytes) == 0 {
+		return privateData, nil
+	}
+
 	err := json.Unmarshal(privateDataBytes, &privateData)
 	if err != nil {
 		return privateData, fmt.Errorf("failed to unmarshal private data: %w", err)
@@ -198,6 +434,41 @@ func (r *TerraformTokenResource) privateData(ctx context.Context, private Privat
 	return privateData, nil
 }
 
+func setTerraformTokenPrivateData(ctx context.Context, private PrivateDataWriter, privateData TerraformTokenPrivateData) error {
+	privateDataBytes, err := json.Marshal(privateData)
+	if err != nil {
+		return err
+	}
+
+	if diags := private.SetKey(ctx, "sa_token_data", privateDataBytes); diags.HasError() {
+		return errors.New("error setting private state")
+	}
+
+	return nil
+}
+
+// tokenIDs returns the service account and token IDs of the resource. Private
+// state is preferred, falling back to the state attributes when it is absent.
+func (r *TerraformTokenResource) tokenIDs(ctx context.Context, private PrivateData, data TerraformTokenResourceModel) (TerraformTokenPrivateData, error) {
+	privateData, err := getTerraformTokenPrivateData(ctx, private)
+	if err != nil {
+		return privateData, err
+	}
+
+	if privateData.ServiceAccountID == "" {
+		privateData.ServiceAccountID = data.ServiceAccountID.ValueString()
+	}
+	if privateData.AuthenticationTokenID == "" {
+		privateData.AuthenticationTokenID = data.ID.ValueString()
+	}
+
+	if privateData.ServiceAccountID == "" || privateData.AuthenticationTokenID == "" {
+		return privateData, errors.New("the service account or token ID is missing from both private state and state")
+	}
+
+	return privateData, nil
+}
+
 func (r *TerraformTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data TerraformTokenResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -206,7 +477,7 @@ func (r *TerraformTokenResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	privateData, err := r.privateData(ctx, req.Private)
+	privateData, err := r.tokenIDs(ctx, req.Private, data)
 	if err != nil {
 		resp.Diagnostics.AddError("Internal error fetching private data for this resource", err.Error())
 		return
@@ -232,12 +503,33 @@ func (r *TerraformTokenResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	err = setTerraformTokenPrivateData(ctx, resp.Private, privateData)
+	if err != nil {
+		resp.Diagnostics.AddError("Internal error storing private data for this resource", err.Error())
+		return
+	}
+
 	r.responseToModel(sa.GetData(), token.GetData(), &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *TerraformTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Not updatable
+	// Tokens are not updatable. The only in-place change is adopting the
+	// configured expires_in after an import, so keep the computed values.
+	var data, state TerraformTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ExpiresAt = state.ExpiresAt
+	data.Token = state.Token
+	data.EncryptedToken = state.EncryptedToken
+	data.KeyFingerprint = state.KeyFingerprint
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *TerraformTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -248,29 +540,44 @@ func (r *TerraformTokenResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	privateData, err := r.privateData(ctx, req.Private)
+	privateData, err := r.tokenIDs(ctx, req.Private, data)
 	if err != nil {
 		resp.Diagnostics.AddError("Internal error fetching private data for this resource", err.Error())
 		return
 	}
 
-	err = r.client.Api().AuthenticationTokens().ByTokenId(privateData.AuthenticationTokenID).Delete(ctx, nil)
-	if err != nil && !IsNotFoundError(err) {
-		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
+	if !deleteServiceAccountToken(ctx, r.client, privateData, &resp.Diagnostics) {
 		return
 	}
 
-	// If we made it this far, remove the resource
 	resp.State.RemoveResource(ctx)
+}
 
-	// Best attempt to delete the service account
-	err = r.client.Api().ServiceAccounts().ByServiceAccountId(privateData.ServiceAccountID).Delete(ctx, nil)
-	if err != nil && !IsNotFoundError(err) {
-		// Warn about the service account not being deleted
-		if apiErrors, ok := err.(*models.Errors); ok {
-			for _, err := range apiErrors.GetErrors() {
-				resp.Diagnostics.AddWarning("Service account resource could not be deleted", fmt.Sprintf("The autenticaton token was deleted, but the associated service account could not be deleted: %s: %s", *err.GetTitle(), *err.GetDetail()))
-			}
-		}
+func (r *TerraformTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	saID, tokenID, ok := strings.Cut(req.ID, "/")
+	if !ok || saID == "" || tokenID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: service_account_id/token_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	privateData := TerraformTokenPrivateData{
+		ServiceAccountID:      saID,
+		AuthenticationTokenID: tokenID,
+	}
+
+	err := setTerraformTokenPrivateData(ctx, resp.Private, privateData)
+	if err != nil {
+		resp.Diagnostics.AddError("Internal error storing private data for this resource", err.Error())
+		return
 	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), tokenID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_account_id"), saID)...)
+	resp.Diagnostics.AddWarning(
+		"Token value cannot be imported",
+		"The secret token value is only returned when a token is created, so the token attribute will be empty for this imported resource. Replace the resource if the token value is needed.",
+	)
 }
@@ -0,0 +1,497 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	abs "github.com/microsoft/kiota-abstractions-go"
+	"github.com/microsoft/kiota-abstractions-go/authentication"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kiotahttp "github.com/microsoft/kiota-http-go"
+	jsonserialization "github.com/microsoft/kiota-serialization-json-go"
+	sdk "github.com/registry-tools/rt-sdk"
+	"github.com/registry-tools/rt-sdk/generated/api"
+)
+
+// testSDK is an sdk.SDK that talks to a fake API server.
+type testSDK struct {
+	api *api.ApiRequestBuilder
+}
+
+func (s *testSDK) Api() *api.ApiRequestBuilder {
+	return s.api
+}
+
+func newTestSDK(t *testing.T, handler http.Handler) sdk.SDK {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	abs.RegisterDefaultSerializer(func() serialization.SerializationWriterFactory {
+		return jsonserialization.NewJsonSerializationWriterFactory()
+	})
+	abs.RegisterDefaultDeserializer(func() serialization.ParseNodeFactory {
+		return jsonserialization.NewJsonParseNodeFactory()
+	})
+
+	adapter, err := kiotahttp.NewNetHttpRequestAdapter(&authentication.AnonymousAuthenticationProvider{})
+	if err != nil {
+		t.Fatalf("Could not create request adapter: %v", err)
+	}
+	adapter.SetBaseUrl(server.URL)
+
+	return &testSDK{api: api.NewApiRequestBuilder(server.URL+"/api", adapter)}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body))
+}
+
+// fakeTokenAPI serves the service account and token endpoints used by
+// createServiceAccountToken and Read, and records which service accounts were
+// deleted.
+type fakeTokenAPI struct {
+	tokenStatus        int
+	deleteStatus       int
+	readStatus         int
+	serviceAccountsDel atomic.Int32
+	tokensDel          atomic.Int32
+}
+
+func (f *fakeTokenAPI) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/namespaces/{namespace}/service_accounts", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, `{"data":{"id":"sa-1","name":"managed-sa provisioner token","role":"provisioner"}}`)
+	})
+	mux.HandleFunc("POST /api/service_accounts/{id}/authentication_tokens", func(w http.ResponseWriter, r *http.Request) {
+		if f.tokenStatus != http.StatusCreated {
+			writeJSON(w, f.tokenStatus, `{"errors":[{"title":"Token failed","detail":"The token could not be created"}]}`)
+			return
+		}
+		writeJSON(w, http.StatusCreated, `{"data":{"id":"token-1","description":"test","token":"secret"}}`)
+	})
+	mux.HandleFunc("GET /api/service_accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if f.readStatus != http.StatusOK {
+			writeJSON(w, f.readStatus, `{"errors":[{"title":"Not found","detail":"The service account could not be found"}]}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"data":{"id":"sa-1","name":"managed-sa provisioner token","role":"provisioner","namespace_id":"ns-1"}}`)
+	})
+	mux.HandleFunc("GET /api/authentication_tokens/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"data":{"id":"token-1","description":"test","expires_at":"2030-01-01T00:00:00Z","service_account_id":"sa-1"}}`)
+	})
+	mux.HandleFunc("DELETE /api/authentication_tokens/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.tokensDel.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /api/service_accounts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.serviceAccountsDel.Add(1)
+		if f.deleteStatus != http.StatusNoContent {
+			writeJSON(w, f.deleteStatus, `{"errors":[{"title":"Delete failed","detail":"The service account could not be deleted"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func TestCreateServiceAccountToken(t *testing.T) {
+	fake := &fakeTokenAPI{tokenStatus: http.StatusCreated, deleteStatus: http.StatusNoContent}
+	client := newTestSDK(t, fake.handler())
+
+	var diags diag.Diagnostics
+	sa, token := createServiceAccountToken(context.Background(), client, "ns-1", "provisioner", "test", "5m", &diags)
+
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	if *sa.GetId() != "sa-1" || *token.GetId() != "token-1" {
+		t.Fatalf("Unexpected service account %q or token %q", *sa.GetId(), *token.GetId())
+	}
+	if n := fake.serviceAccountsDel.Load(); n != 0 {
+		t.Fatalf("Expected no service account to be deleted, got %d", n)
+	}
+}
+
+func TestCreateServiceAccountToken_rollsBackServiceAccount(t *testing.T) {
+	fake := &fakeTokenAPI{tokenStatus: http.StatusUnprocessableEntity, deleteStatus: http.StatusNoContent}
+	client := newTestSDK(t, fake.handler())
+
+	var diags diag.Diagnostics
+	sa, token := createServiceAccountToken(context.Background(), client, "ns-1", "provisioner", "test", "5m", &diags)
+
+	if !diags.HasError() {
+		t.Fatal("Expected an error when the token cannot be created")
+	}
+	if sa != nil || token != nil {
+		t.Fatal("Expected no service account or token to be returned")
+	}
+	if n := fake.serviceAccountsDel.Load(); n != 1 {
+		t.Fatalf("Expected the service account to be deleted once, got %d", n)
+	}
+	if n := diags.WarningsCount(); n != 0 {
+		t.Fatalf("Expected no warnings, got %v", diags.Warnings())
+	}
+}
+
+func TestCreateServiceAccountToken_warnsWhenRollbackFails(t *testing.T) {
+	fake := &fakeTokenAPI{tokenStatus: http.StatusUnprocessableEntity, deleteStatus: http.StatusInternalServerError}
+	client := newTestSDK(t, fake.handler())
+
+	var diags diag.Diagnostics
+	createServiceAccountToken(context.Background(), client, "ns-1", "provisioner", "test", "5m", &diags)
+
+	if !diags.HasError() {
+		t.Fatal("Expected an error when the token cannot be created")
+	}
+	if n := fake.serviceAccountsDel.Load(); n != 1 {
+		t.Fatalf("Expected one attempt to delete the service account, got %d", n)
+	}
+
+	warnings := diags.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "sa-1") {
+		t.Fatalf("Expected a warning naming the orphaned service account, got %v", warnings)
+	}
+}
+
+func TestTerraformTokenResourceModifyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&TerraformTokenResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	newState := func(t *testing.T, expiresAt string) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		for attr, value := range map[string]string{"id": "token-1", "role": "provisioner", "namespace_id": "ns-1", "expires_in": "24h", "expires_at": expiresAt, "token": "secret"} {
+			if diags := state.SetAttribute(ctx, path.Root(attr), value); diags.HasError() {
+				t.Fatalf("Could not set %s: %v", attr, diags)
+			}
+		}
+		return state
+	}
+
+	now := time.Now()
+	cases := map[string]struct {
+		expiresAt   string
+		warnWithin  time.Duration
+		wantReplace bool
+		wantWarning bool
+	}{
+		"expired":                 {expiresAt: now.Add(-time.Minute).Format(time.RFC3339), warnWithin: 48 * time.Hour, wantReplace: true},
+		"expires within window":   {expiresAt: now.Add(time.Hour).Format(time.RFC3339), warnWithin: 2 * time.Hour, wantWarning: true},
+		"expires after window":    {expiresAt: now.Add(3 * time.Hour).Format(time.RFC3339), warnWithin: 2 * time.Hour},
+		"warning disabled":        {expiresAt: now.Add(time.Hour).Format(time.RFC3339)},
+		"unparsable expiry":       {expiresAt: "tomorrow", warnWithin: 2 * time.Hour},
+		"expiry not yet in state": {expiresAt: "", warnWithin: 2 * time.Hour},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &TerraformTokenResource{warnTokenExpiryWithin: c.warnWithin}
+
+			state := newState(t, c.expiresAt)
+			plan := tfsdk.Plan(newState(t, c.expiresAt))
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+			}
+
+			if replace := len(resp.RequiresReplace) > 0; replace != c.wantReplace {
+				t.Fatalf("Expected replacement %t, got %v", c.wantReplace, resp.RequiresReplace)
+			}
+
+			if c.wantReplace {
+				var id types.String
+				resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("id"), &id)...)
+				if !id.IsUnknown() {
+					t.Fatalf("Expected the id to be unknown, got %s", id)
+				}
+			}
+
+			if warned := resp.Diagnostics.WarningsCount() == 1; warned != c.wantWarning {
+				t.Fatalf("Expected warning %t, got %v", c.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestTerraformTokenResourceModifyPlan_create(t *testing.T) {
+	ctx := context.Background()
+	r := &TerraformTokenResource{warnTokenExpiryWithin: time.Hour}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	nullState := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	plan := tfsdk.Plan{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), tftypes.UnknownValue),
+	}
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: nullState, Plan: plan}, resp)
+
+	if len(resp.Diagnostics) > 0 || len(resp.RequiresReplace) > 0 {
+		t.Fatalf("Expected no changes on create, got %v %v", resp.Diagnostics, resp.RequiresReplace)
+	}
+}
+
+func TestParseWarnTokenExpiryWithin(t *testing.T) {
+	cases := map[string]struct {
+		value   types.String
+		want    time.Duration
+		wantErr bool
+	}{
+		"unset":   {value: types.StringNull()},
+		"unknown": {value: types.StringUnknown()},
+		"hours":   {value: types.StringValue("168h"), want: 168 * time.Hour},
+		"mixed":   {value: types.StringValue("1h30m"), want: 90 * time.Minute},
+		"days":    {value: types.StringValue("7d"), wantErr: true},
+		"no unit": {value: types.StringValue("60"), wantErr: true},
+		"empty":   {value: types.StringValue(""), wantErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseWarnTokenExpiryWithin(c.value)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Expected error %t, got %v", c.wantErr, err)
+			}
+			if got != c.want {
+				t.Fatalf("Expected %s, got %s", c.want, got)
+			}
+		})
+	}
+}
+
+// stateAttributes decodes a resource state returned by the provider server.
+func stateAttributes(t *testing.T, objectType tftypes.Type, state *tfprotov6.DynamicValue) map[string]tftypes.Value {
+	t.Helper()
+
+	if state == nil {
+		return nil
+	}
+
+	value, err := state.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("Could not decode the state: %v", err)
+	}
+	if value.IsNull() {
+		return nil
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("Could not decode the state: %v", err)
+	}
+
+	return attributes
+}
+
+func TestTerraformTokenResourceImportStateRead(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeTokenAPI{readStatus: http.StatusOK}
+	server := newTestProviderServer(t, fake.handler())
+
+	var schemaResp resource.SchemaResponse
+	NewTerraformTokenResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	importResp, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: "rt_terraform_token",
+		ID:       "sa-1/token-1",
+	})
+	if err != nil || len(importResp.ImportedResources) != 1 {
+		t.Fatalf("Unexpected import response: %v %v", err, importResp.Diagnostics)
+	}
+	imported := importResp.ImportedResources[0]
+
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "rt_terraform_token",
+		CurrentState: imported.State,
+		Private:      imported.Private,
+	})
+	if err != nil || len(readResp.Diagnostics) > 0 {
+		t.Fatalf("Unexpected error: %v %v", err, readResp.Diagnostics)
+	}
+
+	attributes := stateAttributes(t, objectType, readResp.NewState)
+	want := map[string]string{
+		"id":                   "token-1",
+		"namespace_id":         "ns-1",
+		"role":                 "provisioner",
+		"description":          "test",
+		"expires_at":           "2030-01-01T00:00:00Z",
+		"service_account_id":   "sa-1",
+		"service_account_name": "managed-sa provisioner token",
+	}
+	for attr, value := range want {
+		var got string
+		if err := attributes[attr].As(&got); err != nil || got != value {
+			t.Errorf("Expected %s to be %q, got %s", attr, value, attributes[attr])
+		}
+	}
+	if !attributes["token"].IsNull() {
+		t.Errorf("Expected the token to be null after import, got %s", attributes["token"])
+	}
+}
+
+func TestTerraformTokenResourceRead_notFound(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeTokenAPI{readStatus: http.StatusNotFound}
+	server := newTestProviderServer(t, fake.handler())
+
+	importResp, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: "rt_terraform_token",
+		ID:       "sa-1/token-1",
+	})
+	if err != nil || len(importResp.ImportedResources) != 1 {
+		t.Fatalf("Unexpected import response: %v %v", err, importResp.Diagnostics)
+	}
+	imported := importResp.ImportedResources[0]
+
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "rt_terraform_token",
+		CurrentState: imported.State,
+		Private:      imported.Private,
+	})
+	if err != nil || len(readResp.Diagnostics) > 0 {
+		t.Fatalf("Unexpected error: %v %v", err, readResp.Diagnostics)
+	}
+
+	var schemaResp resource.SchemaResponse
+	NewTerraformTokenResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	if attributes := stateAttributes(t, schemaResp.Schema.Type().TerraformType(ctx), readResp.NewState); attributes != nil {
+		t.Fatalf("Expected the resource to be removed, got %v", attributes)
+	}
+}
+
+func TestTerraformTokenResourceRead_withoutPrivateData(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeTokenAPI{readStatus: http.StatusOK}
+	server := newTestProviderServer(t, fake.handler())
+
+	var schemaResp resource.SchemaResponse
+	NewTerraformTokenResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	// State written before the service account was kept in private state
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, nil),
+	}
+	setTestAttributes(t, &state, map[string]any{
+		"id":                 "token-1",
+		"service_account_id": "sa-1",
+		"role":               "provisioner",
+		"namespace_id":       "ns-1",
+		"expires_in":         "24h",
+	})
+
+	currentState, err := tfprotov6.NewDynamicValue(objectType, state.Raw)
+	if err != nil {
+		t.Fatalf("Could not encode the state: %v", err)
+	}
+
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "rt_terraform_token",
+		CurrentState: &currentState,
+	})
+	if err != nil || len(readResp.Diagnostics) > 0 {
+		t.Fatalf("Unexpected error: %v %v", err, readResp.Diagnostics)
+	}
+
+	attributes := stateAttributes(t, objectType, readResp.NewState)
+	var name string
+	if err := attributes["service_account_name"].As(&name); err != nil || name != "managed-sa provisioner token" {
+		t.Fatalf("Expected the service account to be read, got %s", attributes["service_account_name"])
+	}
+	if len(readResp.Private) == 0 {
+		t.Fatal("Expected the IDs to be stored in private state")
+	}
+}
+
+func TestTerraformTokenResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &TerraformTokenResource{}
+
+	upgrader := r.UpgradeState(ctx)[0]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{
+			Schema: *upgrader.PriorSchema,
+			Raw: tftypes.NewValue(priorType, map[string]tftypes.Value{
+				"role":         tftypes.NewValue(tftypes.String, "provisioner"),
+				"namespace_id": tftypes.NewValue(tftypes.String, "ns-1"),
+				"expires_in":   tftypes.NewValue(tftypes.String, "24h"),
+				"expires_at":   tftypes.NewValue(tftypes.String, "2030-01-01T00:00:00Z"),
+				"description":  tftypes.NewValue(tftypes.String, "test"),
+				"id":           tftypes.NewValue(tftypes.String, "token-1"),
+				"token":        tftypes.NewValue(tftypes.String, "secret"),
+			}),
+		},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+	}
+
+	var upgraded TerraformTokenResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &upgraded)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+	}
+
+	want := TerraformTokenResourceModel{
+		Role:               types.StringValue("provisioner"),
+		Description:        types.StringValue("test"),
+		ID:                 types.StringValue("token-1"),
+		NamespaceID:        types.StringValue("ns-1"),
+		ExpiresIn:          types.StringValue("24h"),
+		ExpiresAt:          types.StringValue("2030-01-01T00:00:00Z"),
+		Token:              types.StringValue("secret"),
+		ServiceAccountID:   types.StringNull(),
+		ServiceAccountName: types.StringNull(),
+		PGPKey:             types.StringNull(),
+		EncryptedToken:     types.StringNull(),
+		KeyFingerprint:     types.StringNull(),
+	}
+	if upgraded != want {
+		t.Fatalf("Expected %+v, got %+v", want, upgraded)
+	}
+}
@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"golang.org/x/crypto/ssh"
+)
+
+var _ validator.String = privateKeyPEMValidator{}
+var _ validator.String = certificatePEMValidator{}
+var _ validator.String = httpsURLValidator{}
+var _ validator.String = httpURLValidator{}
+var _ validator.String = sshPrivateKeyValidator{}
+var _ validator.String = knownHostsValidator{}
+var _ validator.String = durationAtLeastValidator{}
+
+// privateKeyPEMValidator validates that a string is a PEM encoded PKCS #1 or
+// PKCS #8 RSA private key, the only key type GitHub Apps use.
+type privateKeyPEMValidator struct{}
+
+// privateKeyPEM returns a validator which ensures that the configured value is
+// a PEM encoded RSA private key.
+func privateKeyPEM() validator.String {
+	return privateKeyPEMValidator{}
+}
+
+func (v privateKeyPEMValidator) Description(ctx context.Context) string {
+	return "value must be a PEM encoded RSA private key"
+}
+
+func (v privateKeyPEMValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v privateKeyPEMValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := parsePrivateKeyPEM(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Private Key",
+			"The value must be a PEM encoded RSA private key: "+err.Error(),
+		)
+	}
+}
+
+func parsePrivateKeyPEM(value string) error {
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return errors.New("no PEM block found")
+	}
+
+	if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := key.(*rsa.PrivateKey); !ok {
+		return fmt.Errorf("expected an RSA key, got %T", key)
+	}
+
+	return nil
+}
+
+// certificatePEMValidator validates that a string contains one or more PEM
+// encoded X.509 certificates.
+type certificatePEMValidator struct{}
+
+// certificatePEM returns a validator which ensures that the configured value
+// is a bundle of PEM encoded certificates.
+func certificatePEM() validator.String {
+	return certificatePEMValidator{}
+}
+
+func (v certificatePEMValidator) Description(ctx context.Context) string {
+	return "value must contain PEM encoded certificates"
+}
+
+func (v certificatePEMValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v certificatePEMValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := parseCertificatesPEM(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Certificate",
+			"The value must contain PEM encoded certificates: "+err.Error(),
+		)
+	}
+}
+
+func parseCertificatesPEM(value string) error {
+	rest := []byte(value)
+	found := false
+
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return err
+		}
+		found = true
+	}
+
+	if !found {
+		return errors.New("no PEM block found")
+	}
+
+	return nil
+}
+
+// httpsURLValidator validates that a string is an absolute HTTPS URL.
+type httpsURLValidator struct{}
+
+// httpsURL returns a validator which ensures that the configured value is an
+// absolute HTTPS URL.
+func httpsURL() validator.String {
+	return httpsURLValidator{}
+}
+
+func (v httpsURLValidator) Description(ctx context.Context) string {
+	return "value must be an absolute HTTPS URL"
+}
+
+func (v httpsURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v httpsURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := parseHTTPSURL(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			"The value must be an absolute HTTPS URL: "+err.Error(),
+		)
+	}
+}
+
+func parseHTTPSURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "https" {
+		return errors.New("the scheme must be https")
+	}
+
+	if u.Host == "" {
+		return errors.New("the host is missing")
+	}
+
+	return nil
+}
+
+// httpURLValidator validates that a string is an absolute HTTP or HTTPS URL.
+type httpURLValidator struct{}
+
+// httpURL returns a validator which ensures that the configured value is an
+// absolute HTTP or HTTPS URL.
+func httpURL() validator.String {
+	return httpURLValidator{}
+}
+
+func (v httpURLValidator) Description(ctx context.Context) string {
+	return "value must be an absolute HTTP or HTTPS URL"
+}
+
+func (v httpURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v httpURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := parseHTTPURL(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			"The value must be an absolute HTTP or HTTPS URL: "+err.Error(),
+		)
+	}
+}
+
+func parseHTTPURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("the scheme must be http or https")
+	}
+
+	if u.Host == "" {
+		return errors.New("the host is missing")
+	}
+
+	return nil
+}
+
+// sshPrivateKeyValidator validates that a string is an unencrypted SSH
+// private key.
+type sshPrivateKeyValidator struct{}
+
+// sshPrivateKey returns a validator which ensures that the configured value is
+// an unencrypted SSH private key.
+func sshPrivateKey() validator.String {
+	return sshPrivateKeyValidator{}
+}
+
+func (v sshPrivateKeyValidator) Description(ctx context.Context) string {
+	return "value must be an unencrypted SSH private key"
+}
+
+func (v sshPrivateKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v sshPrivateKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := ssh.ParsePrivateKey([]byte(req.ConfigValue.ValueString())); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid SSH Private Key",
+			"The value must be an unencrypted SSH private key: "+err.Error(),
+		)
+	}
+}
+
+// knownHostsValidator validates that a string is in the known_hosts format.
+type knownHostsValidator struct{}
+
+// knownHosts returns a validator which ensures that the configured value
+// contains known_hosts entries.
+func knownHosts() validator.String {
+	return knownHostsValidator{}
+}
+
+func (v knownHostsValidator) Description(ctx context.Context) string {
+	return "value must contain known_hosts entries"
+}
+
+func (v knownHostsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v knownHostsValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, _, _, _, _, err := ssh.ParseKnownHosts([]byte(req.ConfigValue.ValueString())); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Known Hosts",
+			"The value must contain known_hosts entries: "+err.Error(),
+		)
+	}
+}
+
+// durationAtLeastValidator validates that a string is a duration of at least
+// the minimum.
+type durationAtLeastValidator struct {
+	minimum time.Duration
+}
+
+// durationAtLeast returns a validator which ensures that the configured value
+// is a duration, such as 5m, of at least the minimum.
+func durationAtLeast(minimum time.Duration) validator.String {
+	return durationAtLeastValidator{minimum: minimum}
+}
+
+func (v durationAtLeastValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a duration of at least %s", v.minimum)
+}
+
+func (v durationAtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationAtLeastValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("The value must be a duration such as 5m: %v", err),
+		)
+		return
+	}
+
+	if duration < v.minimum {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("The value must be at least %s, got %s.", v.minimum, duration),
+		)
+	}
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPKCS8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := map[string]string{
+		"PKCS #1": string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})),
+		"PKCS #8": string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})),
+	}
+	for name, value := range valid {
+		if err := parsePrivateKeyPEM(value); err != nil {
+			t.Errorf("Expected the %s key to be valid, got %v", name, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"not PEM": "not a key",
+		"garbage": string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte("garbage")})),
+		"empty":   "",
+		"ECDSA":   string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPKCS8})),
+	}
+	for name, value := range invalid {
+		if err := parsePrivateKeyPEM(value); err == nil {
+			t.Errorf("Expected the %s key to be invalid", name)
+		}
+	}
+}
+
+func TestParseHTTPSURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://github.example.com":         true,
+		"https://github.example.com/api/v3/": true,
+		"http://github.example.com":          false,
+		"github.example.com":                 false,
+		"https://":                           false,
+		"://github.example.com":              false,
+	}
+
+	for value, valid := range cases {
+		if err := parseHTTPSURL(value); (err == nil) != valid {
+			t.Errorf("parseHTTPSURL(%q) = %v, expected valid %t", value, err, valid)
+		}
+	}
+}
+
+func TestParseHTTPURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://gitlab.example.com":      true,
+		"http://gitlab.example.com":       true,
+		"http://gitlab.example.com:8080/": true,
+		"gitlab.example.com":              false,
+		"ftp://gitlab.example.com":        false,
+		"http://":                         false,
+		"://gitlab.example.com":           false,
+		"/relative/path":                  false,
+	}
+
+	for value, valid := range cases {
+		if err := parseHTTPURL(value); (err == nil) != valid {
+			t.Errorf("parseHTTPURL(%q) = %v, expected valid %t", value, err, valid)
+		}
+	}
+}
+
+func TestParseCertificatesPEM(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "github.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	if err := parseCertificatesPEM(cert + cert); err != nil {
+		t.Errorf("Expected the certificate bundle to be valid, got %v", err)
+	}
+	if err := parseCertificatesPEM("not a certificate"); err == nil {
+		t.Error("Expected a value without PEM blocks to be invalid")
+	}
+	if err := parseCertificatesPEM(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")}))); err == nil {
+		t.Error("Expected an unparseable certificate to be invalid")
+	}
+}
+
+func TestDurationAtLeastValidator(t *testing.T) {
+	cases := map[string]bool{
+		"5m":    true,
+		"1h30m": true,
+		"1m":    true,
+		"30s":   false,
+		"5":     false,
+		"soon":  false,
+	}
+
+	for value, valid := range cases {
+		req := validator.StringRequest{
+			Path:        path.Root("poll_interval"),
+			ConfigValue: types.StringValue(value),
+		}
+		resp := &validator.StringResponse{}
+
+		durationAtLeast(time.Minute).ValidateString(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("durationAtLeast(1m) for %q: expected valid %t, got %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestSSHPrivateKeyValidator(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte("passphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		value string
+		valid bool
+	}{
+		"unencrypted": {string(pem.EncodeToMemory(block)), true},
+		"encrypted":   {string(pem.EncodeToMemory(encrypted)), false},
+		"garbage":     {"not a key", false},
+	}
+
+	for name, c := range cases {
+		req := validator.StringRequest{
+			Path:        path.Root("ssh_private_key"),
+			ConfigValue: types.StringValue(c.value),
+		}
+		resp := &validator.StringResponse{}
+
+		sshPrivateKey().ValidateString(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() == c.valid {
+			t.Errorf("sshPrivateKey for the %s key: expected valid %t, got %v", name, c.valid, resp.Diagnostics)
+		}
+	}
+}
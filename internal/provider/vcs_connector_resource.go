@@ -3,22 +3,49 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	sdk "github.com/registry-tools/rt-sdk"
+	"github.com/registry-tools/rt-sdk/generated/api"
 	"github.com/registry-tools/rt-sdk/generated/models"
 )
 
+// The connector types, as named by the provider field of the API.
+const (
+	vcsProviderGitHub      = "github"
+	vcsProviderGitLab      = "gitlab"
+	vcsProviderBitbucket   = "bitbucket"
+	vcsProviderAzureDevOps = "azure_devops"
+	vcsProviderGit         = "git"
+)
+
+// vcsCredentialExpiryThreshold is how long a credential must remain valid to
+// pass verification.
+const vcsCredentialExpiryThreshold = 7 * 24 * time.Hour
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &VCSConnectorResource{}
 var _ resource.ResourceWithImportState = &VCSConnectorResource{}
+var _ resource.ResourceWithUpgradeState = &VCSConnectorResource{}
+var _ resource.ResourceWithConfigValidators = &VCSConnectorResource{}
 
 func NewVCSConnectorResource() resource.Resource {
 	return &VCSConnectorResource{}
@@ -31,23 +58,99 @@ type VCSConnectorResource struct {
 
 // VCSConnectorResourceModel describes the resource data model.
 type VCSConnectorResourceModel struct {
+	ID          types.String                  `tfsdk:"id"`
+	GitHub      *VCSConnectorGitHubModel      `tfsdk:"github"`
+	GitLab      *VCSConnectorGitLabModel      `tfsdk:"gitlab"`
+	Bitbucket   *VCSConnectorBitbucketModel   `tfsdk:"bitbucket"`
+	AzureDevOps *VCSConnectorAzureDevOpsModel `tfsdk:"azure_devops"`
+	Git         *VCSConnectorGitModel         `tfsdk:"git"`
+	Description types.String                  `tfsdk:"description"`
+	Verify      types.Bool                    `tfsdk:"verify"`
+	VerifiedAt  types.String                  `tfsdk:"verified_at"`
+	Scopes      types.List                    `tfsdk:"scopes"`
+}
+
+// VCSConnectorGitHubModel describes the github block of the data model.
+type VCSConnectorGitHubModel struct {
+	Token          types.String `tfsdk:"token"`
+	TokenWO        types.String `tfsdk:"token_wo"`
+	TokenWOVersion types.Int64  `tfsdk:"token_wo_version"`
+	AppID          types.Int64  `tfsdk:"app_id"`
+	InstallationID types.Int64  `tfsdk:"installation_id"`
+	PrivateKeyPEM  types.String `tfsdk:"private_key_pem"`
+	BaseURL        types.String `tfsdk:"base_url"`
+	CACertPEM      types.String `tfsdk:"ca_cert_pem"`
+}
+
+// VCSConnectorGitLabModel describes the gitlab block of the data model.
+type VCSConnectorGitLabModel struct {
+	Token   types.String `tfsdk:"token"`
+	BaseURL types.String `tfsdk:"base_url"`
+}
+
+// VCSConnectorBitbucketModel describes the bitbucket block of the data model.
+type VCSConnectorBitbucketModel struct {
+	Workspace   types.String `tfsdk:"workspace"`
+	Username    types.String `tfsdk:"username"`
+	AppPassword types.String `tfsdk:"app_password"`
+	AccessToken types.String `tfsdk:"access_token"`
+	ServerURL   types.String `tfsdk:"server_url"`
+}
+
+// VCSConnectorAzureDevOpsModel describes the azure_devops block of the data
+// model.
+type VCSConnectorAzureDevOpsModel struct {
+	OrganizationURL types.String `tfsdk:"organization_url"`
+	Token           types.String `tfsdk:"token"`
+}
+
+// VCSConnectorGitModel describes the git block of the data model.
+type VCSConnectorGitModel struct {
+	CloneURL      types.String `tfsdk:"clone_url"`
+	Username      types.String `tfsdk:"username"`
+	Password      types.String `tfsdk:"password"`
+	SSHPrivateKey types.String `tfsdk:"ssh_private_key"`
+	KnownHosts    types.String `tfsdk:"known_hosts"`
+	PollInterval  types.String `tfsdk:"poll_interval"`
+}
+
+// vcsConnectorResourceModelV0 describes the schema version 0 data model, in
+// which github was an object attribute.
+type vcsConnectorResourceModelV0 struct {
 	ID          types.String `tfsdk:"id"`
 	GitHub      types.Object `tfsdk:"github"`
 	Description types.String `tfsdk:"description"`
 }
 
+// vcsConnectorGitHubModelV0 describes the github object of the schema version
+// 0 data model.
+type vcsConnectorGitHubModelV0 struct {
+	Token types.String `tfsdk:"token"`
+}
+
 func (r *VCSConnectorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_vcs_connector"
 }
 
 func (r *VCSConnectorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"description": schema.StringAttribute{
 				Optional: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+			},
+			"verify": schema.BoolAttribute{
+				MarkdownDescription: "Verify the credentials on create and update: that they authenticate, have the scopes required to publish tags and do not expire within 7 days.",
+				Optional:            true,
+			},
+			"verified_at": schema.StringAttribute{
+				MarkdownDescription: "When the credentials were last verified, if `verify` is set.",
+				Computed:            true,
+			},
+			"scopes": schema.ListAttribute{
+				MarkdownDescription: "The scopes granted to the credentials, as found by the last verification.",
+				ElementType:         types.StringType,
+				Computed:            true,
 			},
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -55,19 +158,321 @@ func (r *VCSConnectorResource) Schema(ctx context.Context, req resource.SchemaRe
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"github": schema.ObjectAttribute{
-				AttributeTypes: map[string]attr.Type{
-					"token": types.StringType,
+			"github": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"token": schema.StringAttribute{
+						MarkdownDescription: "The GitHub personal access token. Exactly one of `token`, `token_wo` or `app_id` must be set.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(
+								path.MatchRelative().AtParent().AtName("token_wo"),
+								path.MatchRelative().AtParent().AtName("app_id"),
+							),
+						},
+					},
+					"token_wo": schema.StringAttribute{
+						MarkdownDescription: "The GitHub personal access token, which is never stored in state. Requires Terraform 1.11 or later, use `token` with older versions.",
+						Optional:            true,
+						Sensitive:           true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("token_wo_version")),
+						},
+					},
+					"token_wo_version": schema.Int64Attribute{
+						MarkdownDescription: "Change this value to update the connector with the current `token_wo`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("token_wo")),
+						},
+					},
+					"app_id": schema.Int64Attribute{
+						MarkdownDescription: "The ID of the GitHub App to authenticate as, instead of a personal access token.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+							int64validator.AlsoRequires(
+								path.MatchRelative().AtParent().AtName("installation_id"),
+								path.MatchRelative().AtParent().AtName("private_key_pem"),
+							),
+						},
+					},
+					"installation_id": schema.Int64Attribute{
+						MarkdownDescription: "The ID of the installation of the GitHub App on the account that owns the repositories.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("app_id")),
+						},
+					},
+					"private_key_pem": schema.StringAttribute{
+						MarkdownDescription: "The PEM encoded RSA private key of the GitHub App.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							privateKeyPEM(),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("app_id")),
+						},
+					},
+					"base_url": schema.StringAttribute{
+						MarkdownDescription: "The URL of a GitHub Enterprise Server instance, such as `https://github.example.com`. Defaults to GitHub.com.",
+						Optional:            true,
+						Validators: []validator.String{
+							httpsURL(),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"ca_cert_pem": schema.StringAttribute{
+						MarkdownDescription: "The PEM encoded CA certificates to trust for the GitHub Enterprise Server instance, when it does not use a publicly trusted certificate.",
+						Optional:            true,
+						Validators: []validator.String{
+							certificatePEM(),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("base_url")),
+						},
+					},
 				},
-				Required: true,
+				Optional: true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.RequiresReplace(),
+					requiresReplaceIfConnectorTypeChanged(),
+				},
+			},
+			"gitlab": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"token": schema.StringAttribute{
+						MarkdownDescription: "The GitLab personal, group or project access token.",
+						Required:            true,
+						Sensitive:           true,
+					},
+					"base_url": schema.StringAttribute{
+						MarkdownDescription: "The URL of a self-managed GitLab instance. Defaults to GitLab.com.",
+						Optional:            true,
+						Validators: []validator.String{
+							httpURL(),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfConnectorTypeChanged(),
+				},
+			},
+			"bitbucket": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"workspace": schema.StringAttribute{
+						MarkdownDescription: "The Bitbucket Cloud workspace or Bitbucket Data Center project key.",
+						Required:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"username": schema.StringAttribute{
+						MarkdownDescription: "The Bitbucket username that owns `app_password`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("app_password")),
+						},
+					},
+					"app_password": schema.StringAttribute{
+						MarkdownDescription: "The Bitbucket app password. Exactly one of `app_password` or `access_token` must be set.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("access_token")),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("username")),
+						},
+					},
+					"access_token": schema.StringAttribute{
+						MarkdownDescription: "The Bitbucket workspace, project or repository access token.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"server_url": schema.StringAttribute{
+						MarkdownDescription: "The URL of a Bitbucket Data Center instance. Defaults to Bitbucket Cloud.",
+						Optional:            true,
+						Validators: []validator.String{
+							httpURL(),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfConnectorTypeChanged(),
+				},
+			},
+			"azure_devops": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"organization_url": schema.StringAttribute{
+						MarkdownDescription: "The URL of the Azure DevOps organization, such as `https://dev.azure.com/my-org`.",
+						Required:            true,
+						Validators: []validator.String{
+							httpURL(),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"token": schema.StringAttribute{
+						MarkdownDescription: "The Azure DevOps personal access token.",
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfConnectorTypeChanged(),
+				},
+			},
+			"git": schema.SingleNestedAttribute{
+				MarkdownDescription: "A plain Git server, such as Gitea. Tag publishers discover tags by polling the repository instead of through webhooks.",
+				Attributes: map[string]schema.Attribute{
+					"clone_url": schema.StringAttribute{
+						MarkdownDescription: "The clone URL pattern, in which `{repo}` is replaced by the repository identifier of the tag publisher, such as `https://git.example.com/{repo}.git` or `git@git.example.com:{repo}.git`.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`\{repo\}`), "must contain the {repo} placeholder"),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"username": schema.StringAttribute{
+						MarkdownDescription: "The username for HTTPS basic authentication.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+						},
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "The password or token for HTTPS basic authentication. Exactly one of `password` or `ssh_private_key` must be set.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("ssh_private_key")),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("username")),
+						},
+					},
+					"ssh_private_key": schema.StringAttribute{
+						MarkdownDescription: "The unencrypted SSH deploy private key.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							sshPrivateKey(),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("known_hosts")),
+						},
+					},
+					"known_hosts": schema.StringAttribute{
+						MarkdownDescription: "The `known_hosts` entry of the Git server, used to verify its SSH host key.",
+						Optional:            true,
+						Validators: []validator.String{
+							knownHosts(),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("ssh_private_key")),
+						},
+					},
+					"poll_interval": schema.StringAttribute{
+						MarkdownDescription: "How often tag publishers poll the repositories for new tags, as a duration such as `15m`. Defaults to `5m`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("5m"),
+						Validators: []validator.String{
+							durationAtLeast(time.Minute),
+						},
+					},
+				},
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					requiresReplaceIfConnectorTypeChanged(),
 				},
 			},
 		},
 	}
 }
 
+func (r *VCSConnectorResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("github"),
+			path.MatchRoot("gitlab"),
+			path.MatchRoot("bitbucket"),
+			path.MatchRoot("azure_devops"),
+			path.MatchRoot("git"),
+		),
+	}
+}
+
+// requiresReplaceIfConnectorTypeChanged replaces the connector when a
+// connector type block is added, which means the type of connector changed.
+func requiresReplaceIfConnectorTypeChanged() planmodifier.Object {
+	return objectplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = req.StateValue.IsNull() && !req.PlanValue.IsNull()
+		},
+		"Changing the connector type requires a new connector.",
+		"Changing the connector type requires a new connector.",
+	)
+}
+
+func (r *VCSConnectorResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"description": schema.StringAttribute{Optional: true},
+					"id":          schema.StringAttribute{Computed: true},
+					"github": schema.ObjectAttribute{
+						AttributeTypes: map[string]attr.Type{
+							"token": types.StringType,
+						},
+						Required: true,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior vcsConnectorResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := VCSConnectorResourceModel{
+					ID:          prior.ID,
+					Description: prior.Description,
+					Verify:      types.BoolNull(),
+					VerifiedAt:  types.StringNull(),
+					Scopes:      types.ListNull(types.StringType),
+				}
+
+				if !prior.GitHub.IsNull() {
+					var github vcsConnectorGitHubModelV0
+					resp.Diagnostics.Append(prior.GitHub.As(ctx, &github, basetypes.ObjectAsOptions{})...)
+
+					upgraded.GitHub = &VCSConnectorGitHubModel{
+						Token:          github.Token,
+						TokenWO:        types.StringNull(),
+						TokenWOVersion: types.Int64Null(),
+						AppID:          types.Int64Null(),
+						InstallationID: types.Int64Null(),
+						PrivateKeyPEM:  types.StringNull(),
+						BaseURL:        types.StringNull(),
+						CACertPEM:      types.StringNull(),
+					}
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
+
 func (r *VCSConnectorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -88,7 +493,128 @@ func (r *VCSConnectorResource) Configure(ctx context.Context, req resource.Confi
 }
 
 func (r *VCSConnectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "VCS Connectors cannot be updated. This is a bug in the provider.")
+	var data, state VCSConnectorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateConnector := r.modelToRequest(ctx, req.Config, data, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateConnectorBody := api.NewVcsConnectorsPatchRequestBody()
+	updateConnectorBody.SetVcsConnector(updateConnector)
+
+	vcsConnector, err := r.client.Api().VcsConnectors().ByVcsConnectorId(state.ID.ValueString()).PatchAsVcsConnectorPatchResponse(ctx, updateConnectorBody, nil)
+	if err != nil {
+		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
+		return
+	}
+
+	r.responseToModel(vcsConnector.GetData(), &data)
+	r.verify(ctx, &data, &resp.Diagnostics)
+
+	// The connector was updated even when the verification failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// modelToRequest builds the connector sent to the API. On update, the prior
+// state is given and the credentials are only sent when they were rotated.
+func (r *VCSConnectorResource) modelToRequest(ctx context.Context, config tfsdk.Config, data VCSConnectorResourceModel, state *VCSConnectorResourceModel, diags *diag.Diagnostics) models.VCSConnectorable {
+	connector := models.NewVCSConnector()
+
+	description := ""
+	if !data.Description.IsNull() {
+		description = data.Description.ValueString()
+	}
+	connector.SetDescription(&description)
+
+	provider := ""
+	switch {
+	case data.GitHub != nil:
+		provider = vcsProviderGitHub
+		connector.SetBaseUrl(data.GitHub.BaseURL.ValueStringPointer())
+
+		// The API clears the CA certificates when an empty string is sent
+		caCert := data.GitHub.CACertPEM.ValueString()
+		connector.SetCaCertificate(&caCert)
+
+		if !data.GitHub.AppID.IsNull() {
+			if state == nil || !data.GitHub.AppID.Equal(state.GitHub.AppID) || !data.GitHub.InstallationID.Equal(state.GitHub.InstallationID) || !data.GitHub.PrivateKeyPEM.Equal(state.GitHub.PrivateKeyPEM) {
+				connector.SetAppId(data.GitHub.AppID.ValueInt64Pointer())
+				connector.SetInstallationId(data.GitHub.InstallationID.ValueInt64Pointer())
+				connector.SetPrivateKey(data.GitHub.PrivateKeyPEM.ValueStringPointer())
+			}
+		} else if state == nil || !data.GitHub.Token.Equal(state.GitHub.Token) || !data.GitHub.TokenWOVersion.Equal(state.GitHub.TokenWOVersion) {
+			// Send the token when it was rotated, either by changing token or
+			// by bumping token_wo_version for the write-only token.
+			token := r.githubToken(ctx, config, diags)
+			connector.SetToken(&token)
+		}
+	case data.GitLab != nil:
+		provider = vcsProviderGitLab
+		connector.SetBaseUrl(data.GitLab.BaseURL.ValueStringPointer())
+
+		if state == nil || !data.GitLab.Token.Equal(state.GitLab.Token) {
+			connector.SetToken(data.GitLab.Token.ValueStringPointer())
+		}
+	case data.Bitbucket != nil:
+		provider = vcsProviderBitbucket
+		connector.SetWorkspace(data.Bitbucket.Workspace.ValueStringPointer())
+		connector.SetBaseUrl(data.Bitbucket.ServerURL.ValueStringPointer())
+
+		if state == nil || !data.Bitbucket.Username.Equal(state.Bitbucket.Username) || !data.Bitbucket.AppPassword.Equal(state.Bitbucket.AppPassword) || !data.Bitbucket.AccessToken.Equal(state.Bitbucket.AccessToken) {
+			// An access token is sent without a username
+			connector.SetUsername(data.Bitbucket.Username.ValueStringPointer())
+			if !data.Bitbucket.AppPassword.IsNull() {
+				connector.SetToken(data.Bitbucket.AppPassword.ValueStringPointer())
+			} else {
+				connector.SetToken(data.Bitbucket.AccessToken.ValueStringPointer())
+			}
+		}
+	case data.AzureDevOps != nil:
+		provider = vcsProviderAzureDevOps
+		connector.SetBaseUrl(data.AzureDevOps.OrganizationURL.ValueStringPointer())
+
+		if state == nil || !data.AzureDevOps.Token.Equal(state.AzureDevOps.Token) {
+			connector.SetToken(data.AzureDevOps.Token.ValueStringPointer())
+		}
+	case data.Git != nil:
+		provider = vcsProviderGit
+		connector.SetCloneUrl(data.Git.CloneURL.ValueStringPointer())
+		connector.SetPollInterval(data.Git.PollInterval.ValueStringPointer())
+
+		if state == nil || !data.Git.Username.Equal(state.Git.Username) || !data.Git.Password.Equal(state.Git.Password) || !data.Git.SSHPrivateKey.Equal(state.Git.SSHPrivateKey) || !data.Git.KnownHosts.Equal(state.Git.KnownHosts) {
+			connector.SetUsername(data.Git.Username.ValueStringPointer())
+			connector.SetToken(data.Git.Password.ValueStringPointer())
+			connector.SetSshPrivateKey(data.Git.SSHPrivateKey.ValueStringPointer())
+			connector.SetKnownHosts(data.Git.KnownHosts.ValueStringPointer())
+		}
+	}
+
+	// The connector type cannot be changed after creation
+	if state == nil {
+		connector.SetProvider(&provider)
+	}
+
+	return connector
+}
+
+// githubToken returns the GitHub token from the configuration. The write-only
+// token_wo is only available in the configuration, never in plan or state.
+func (r *VCSConnectorResource) githubToken(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) string {
+	var token types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("github").AtName("token"), &token)...)
+
+	if token.IsNull() {
+		diags.Append(config.GetAttribute(ctx, path.Root("github").AtName("token_wo"), &token)...)
+	}
+
+	return token.ValueString()
 }
 
 func (r *VCSConnectorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,20 +625,12 @@ func (r *VCSConnectorResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	tokenAsString, ok := data.GitHub.Attributes()["token"].(types.String)
-	if !ok {
-		resp.Diagnostics.AddAttributeError(path.Root("github").AtName("token"), "Attribute type error", fmt.Sprintf("Expected string, got %T", data.GitHub.Attributes()["token"]))
+	newConnector := r.modelToRequest(ctx, req.Config, data, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	token := tokenAsString.ValueString()
-
-	github := "github"
-	newGitHubConnector := models.NewVCSConnector()
-	newGitHubConnector.SetDescription(data.Description.ValueStringPointer())
-	newGitHubConnector.SetToken(&token)
-	newGitHubConnector.SetProvider(&github)
 
-	vcsConnector, err := r.client.Api().VcsConnectors().PostAsVcsConnectorsPostResponse(ctx, newGitHubConnector, nil)
+	vcsConnector, err := r.client.Api().VcsConnectors().PostAsVcsConnectorsPostResponse(ctx, newConnector, nil)
 	if err != nil {
 		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
 		return
@@ -120,22 +638,209 @@ func (r *VCSConnectorResource) Create(ctx context.Context, req resource.CreateRe
 
 	r.responseToModel(vcsConnector.GetData(), &data)
 
+	r.verify(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		// Roll back the connector, so that it is created again once the
+		// credentials are fixed
+		err := r.client.Api().VcsConnectors().ByVcsConnectorId(data.ID.ValueString()).Delete(ctx, nil)
+		if err != nil && !IsNotFoundError(err) {
+			resp.Diagnostics.AddWarning(
+				"VCS connector could not be rolled back",
+				fmt.Sprintf("The VCS connector %s failed verification and could not be deleted, it must be deleted manually: %v", data.ID.ValueString(), err),
+			)
+		}
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// verify checks the credentials of the connector through the API when verify
+// is set, and records the outcome in verified_at and scopes. Failures are
+// reported against the credential attribute.
+func (r *VCSConnectorResource) verify(ctx context.Context, data *VCSConnectorResourceModel, diags *diag.Diagnostics) {
+	data.VerifiedAt = types.StringNull()
+	data.Scopes = types.ListNull(types.StringType)
+
+	if !data.Verify.ValueBool() {
+		return
+	}
+
+	response, err := r.client.Api().VcsConnectors().ByVcsConnectorId(data.ID.ValueString()).Verify().PostAsVerifyPostResponse(ctx, nil)
+	if err != nil {
+		APIErrorsAsDiagnostics(err, diags)
+		return
+	}
+
+	verification := response.GetData()
+	credential := data.credentialPath()
+
+	if authenticated := verification.GetAuthenticated(); authenticated == nil || !*authenticated {
+		diags.AddAttributeError(
+			credential,
+			"Credential Verification Failed",
+			fmt.Sprintf("The VCS connector could not authenticate with the credentials: %s", types.StringPointerValue(verification.GetMessage()).ValueString()),
+		)
+		return
+	}
+
+	if missing := verification.GetMissingScopes(); len(missing) > 0 {
+		diags.AddAttributeError(
+			credential,
+			"Credential Verification Failed",
+			fmt.Sprintf("The credentials are missing the scopes required to publish tags: %s.", strings.Join(missing, ", ")),
+		)
+	}
+
+	if expiresAt := verification.GetTokenExpiresAt(); expiresAt != nil && time.Until(*expiresAt) < vcsCredentialExpiryThreshold {
+		diags.AddAttributeError(
+			credential,
+			"Credential Verification Failed",
+			fmt.Sprintf("The credentials expire at %s, within %s.", expiresAt.Format(time.RFC3339), vcsCredentialExpiryThreshold),
+		)
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	data.VerifiedAt = timeValueOrNull(verification.GetVerifiedAt())
+
+	scopes, scopeDiags := types.ListValueFrom(ctx, types.StringType, verification.GetScopes())
+	diags.Append(scopeDiags...)
+	data.Scopes = scopes
+}
+
+// credentialPath returns the path of the configured credential attribute,
+// which verification failures are reported against.
+func (m VCSConnectorResourceModel) credentialPath() path.Path {
+	switch {
+	case m.GitHub != nil:
+		if !m.GitHub.AppID.IsNull() {
+			return path.Root("github").AtName("private_key_pem")
+		}
+		if !m.GitHub.Token.IsNull() {
+			return path.Root("github").AtName("token")
+		}
+		return path.Root("github").AtName("token_wo")
+	case m.GitLab != nil:
+		return path.Root("gitlab").AtName("token")
+	case m.Bitbucket != nil:
+		if !m.Bitbucket.AppPassword.IsNull() {
+			return path.Root("bitbucket").AtName("app_password")
+		}
+		return path.Root("bitbucket").AtName("access_token")
+	case m.AzureDevOps != nil:
+		return path.Root("azure_devops").AtName("token")
+	case m.Git != nil:
+		if !m.Git.Password.IsNull() {
+			return path.Root("git").AtName("password")
+		}
+		return path.Root("git").AtName("ssh_private_key")
+	}
+
+	return path.Empty()
+}
+
 func (r *VCSConnectorResource) responseToModel(response models.VCSConnectorable, model *VCSConnectorResourceModel) {
 	model.ID = types.StringPointerValue(response.GetId())
 
-	description := response.GetDescription()
-	if *description != "" {
-		model.Description = types.StringPointerValue(description)
+	model.Description = stringValueOrNull(response.GetDescription())
+
+	// Credentials are write-only in the API, so they are kept from state. The
+	// block of the connector type is created when it is missing, such as
+	// after an import, and any other block is removed.
+	provider := ""
+	if response.GetProvider() != nil {
+		provider = *response.GetProvider()
+	}
+
+	if provider != vcsProviderGitHub {
+		model.GitHub = nil
+	} else {
+		if model.GitHub == nil {
+			model.GitHub = &VCSConnectorGitHubModel{
+				Token:          types.StringNull(),
+				TokenWO:        types.StringNull(),
+				TokenWOVersion: types.Int64Null(),
+				PrivateKeyPEM:  types.StringNull(),
+			}
+		}
+		model.GitHub.BaseURL = stringValueOrNull(response.GetBaseUrl())
+		model.GitHub.CACertPEM = stringValueOrNull(response.GetCaCertificate())
+		model.GitHub.AppID = types.Int64PointerValue(response.GetAppId())
+		model.GitHub.InstallationID = types.Int64PointerValue(response.GetInstallationId())
+	}
+
+	if provider != vcsProviderGitLab {
+		model.GitLab = nil
+	} else {
+		if model.GitLab == nil {
+			model.GitLab = &VCSConnectorGitLabModel{Token: types.StringNull()}
+		}
+		model.GitLab.BaseURL = stringValueOrNull(response.GetBaseUrl())
+	}
+
+	if provider != vcsProviderBitbucket {
+		model.Bitbucket = nil
+	} else {
+		if model.Bitbucket == nil {
+			model.Bitbucket = &VCSConnectorBitbucketModel{
+				AppPassword: types.StringNull(),
+				AccessToken: types.StringNull(),
+			}
+		}
+		model.Bitbucket.Workspace = types.StringPointerValue(response.GetWorkspace())
+		model.Bitbucket.Username = stringValueOrNull(response.GetUsername())
+		model.Bitbucket.ServerURL = stringValueOrNull(response.GetBaseUrl())
+	}
+
+	if provider != vcsProviderAzureDevOps {
+		model.AzureDevOps = nil
+	} else {
+		if model.AzureDevOps == nil {
+			model.AzureDevOps = &VCSConnectorAzureDevOpsModel{Token: types.StringNull()}
+		}
+		model.AzureDevOps.OrganizationURL = types.StringPointerValue(response.GetBaseUrl())
+	}
+
+	if provider != vcsProviderGit {
+		model.Git = nil
+	} else {
+		if model.Git == nil {
+			model.Git = &VCSConnectorGitModel{
+				Password:      types.StringNull(),
+				SSHPrivateKey: types.StringNull(),
+			}
+		}
+		model.Git.CloneURL = types.StringPointerValue(response.GetCloneUrl())
+		model.Git.Username = stringValueOrNull(response.GetUsername())
+		model.Git.KnownHosts = stringValueOrNull(response.GetKnownHosts())
+		model.Git.PollInterval = durationValue(model.Git.PollInterval, response.GetPollInterval())
 	}
 }
 
 func (r *VCSConnectorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// Reads only from state
 	var data VCSConnectorResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vcsConnector, err := r.client.Api().VcsConnectors().ByVcsConnectorId(data.ID.ValueString()).GetAsVcsConnectorGetResponse(ctx, nil)
+	if err != nil {
+		if IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		APIErrorsAsDiagnostics(err, &resp.Diagnostics)
+		return
+	}
+
+	r.responseToModel(vcsConnector.GetData(), &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *VCSConnectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -160,3 +865,42 @@ func (r *VCSConnectorResource) Delete(ctx context.Context, req resource.DeleteRe
 func (r *VCSConnectorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// stringValueOrNull returns a null string for nil or empty API values, which
+// the API uses interchangeably for unset optional fields.
+func stringValueOrNull(value *string) types.String {
+	if value == nil || *value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(*value)
+}
+
+// timeValueOrNull returns the time as an RFC 3339 string, or a null string for
+// nil API values.
+func timeValueOrNull(value *time.Time) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value.Format(time.RFC3339))
+}
+
+// durationValue returns the duration from the API, keeping the prior value
+// when the API omits it or returns the same duration in another format, such
+// as 300s for 5m.
+func durationValue(prior types.String, value *string) types.String {
+	if value == nil {
+		return prior
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		priorDuration, priorErr := time.ParseDuration(prior.ValueString())
+		duration, err := time.ParseDuration(*value)
+		if priorErr == nil && err == nil && duration == priorDuration {
+			return prior
+		}
+	}
+
+	return types.StringValue(*value)
+}
@@ -0,0 +1,896 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/registry-tools/rt-sdk/generated/models"
+)
+
+func TestVCSConnectorResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &VCSConnectorResource{}
+
+	upgrader := r.UpgradeState(ctx)[0]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	githubType := priorType.(tftypes.Object).AttributeTypes["github"]
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{
+			Schema: *upgrader.PriorSchema,
+			Raw: tftypes.NewValue(priorType, map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, "connector-1"),
+				"description": tftypes.NewValue(tftypes.String, "test"),
+				"github": tftypes.NewValue(githubType, map[string]tftypes.Value{
+					"token": tftypes.NewValue(tftypes.String, "secret"),
+				}),
+			}),
+		},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+	}
+
+	var upgraded VCSConnectorResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &upgraded)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+	}
+
+	if upgraded.GitHub == nil || upgraded.GitHub.Token.ValueString() != "secret" {
+		t.Fatalf("Expected the github token to be kept, got %+v", upgraded.GitHub)
+	}
+	if !upgraded.GitHub.TokenWO.IsNull() || !upgraded.GitHub.TokenWOVersion.IsNull() {
+		t.Fatalf("Expected the write-only token to be null, got %+v", upgraded.GitHub)
+	}
+	if upgraded.GitLab != nil {
+		t.Fatalf("Expected no gitlab block, got %+v", upgraded.GitLab)
+	}
+}
+
+func TestVCSConnectorResourceVerify(t *testing.T) {
+	expiresAt := time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	expiresSoon := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	cases := map[string]struct {
+		verification string
+		errorPath    path.Path
+	}{
+		"verified": {
+			verification: `{"authenticated":true,"scopes":["repo","admin:repo_hook"],"token_expires_at":"` + expiresAt + `","verified_at":"2026-10-18T12:00:00Z"}`,
+		},
+		"not authenticated": {
+			verification: `{"authenticated":false,"message":"Bad credentials"}`,
+			errorPath:    path.Root("github").AtName("token"),
+		},
+		"missing scopes": {
+			verification: `{"authenticated":true,"scopes":["repo"],"missing_scopes":["admin:repo_hook"]}`,
+			errorPath:    path.Root("github").AtName("token"),
+		},
+		"expires soon": {
+			verification: `{"authenticated":true,"scopes":["repo","admin:repo_hook"],"token_expires_at":"` + expiresSoon + `"}`,
+			errorPath:    path.Root("github").AtName("token"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /api/vcs_connectors/{id}/verify", func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, `{"data":`+c.verification+`}`)
+			})
+			r := &VCSConnectorResource{client: newTestSDK(t, mux)}
+
+			data := VCSConnectorResourceModel{
+				ID:     types.StringValue("connector-1"),
+				Verify: types.BoolValue(true),
+				GitHub: &VCSConnectorGitHubModel{
+					Token: types.StringValue("secret"),
+					AppID: types.Int64Null(),
+				},
+			}
+
+			var diags diag.Diagnostics
+			r.verify(context.Background(), &data, &diags)
+
+			if len(c.errorPath.Steps()) == 0 {
+				if diags.HasError() {
+					t.Fatalf("Unexpected error: %v", diags)
+				}
+				if data.VerifiedAt.ValueString() != "2026-10-18T12:00:00Z" {
+					t.Fatalf("Expected verified_at to be set, got %s", data.VerifiedAt)
+				}
+				if len(data.Scopes.Elements()) != 2 {
+					t.Fatalf("Expected two scopes, got %s", data.Scopes)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("Expected one error, got %v", diags)
+			}
+			withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(c.errorPath) {
+				t.Fatalf("Expected the error to be reported against %s, got %v", c.errorPath, diags.Errors()[0])
+			}
+			if !data.VerifiedAt.IsNull() || !data.Scopes.IsNull() {
+				t.Fatalf("Expected verified_at and scopes to be null, got %s and %s", data.VerifiedAt, data.Scopes)
+			}
+		})
+	}
+}
+
+func TestVCSConnectorResourceModelToRequestGitLab(t *testing.T) {
+	ctx := context.Background()
+	r := &VCSConnectorResource{}
+
+	cases := map[string]struct {
+		data        VCSConnectorGitLabModel
+		state       *VCSConnectorGitLabModel
+		wantToken   *string
+		wantBaseURL *string
+		wantType    *string
+	}{
+		"create on GitLab.com": {
+			data:      VCSConnectorGitLabModel{Token: types.StringValue("glpat-1"), BaseURL: types.StringNull()},
+			wantToken: ptr("glpat-1"),
+			wantType:  ptr(vcsProviderGitLab),
+		},
+		"create self-managed": {
+			data:        VCSConnectorGitLabModel{Token: types.StringValue("glpat-1"), BaseURL: types.StringValue("https://gitlab.example.com")},
+			wantToken:   ptr("glpat-1"),
+			wantBaseURL: ptr("https://gitlab.example.com"),
+			wantType:    ptr(vcsProviderGitLab),
+		},
+		"update without rotation": {
+			data:        VCSConnectorGitLabModel{Token: types.StringValue("glpat-1"), BaseURL: types.StringValue("https://gitlab.example.com")},
+			state:       &VCSConnectorGitLabModel{Token: types.StringValue("glpat-1"), BaseURL: types.StringNull()},
+			wantBaseURL: ptr("https://gitlab.example.com"),
+		},
+		"update with rotation": {
+			data:      VCSConnectorGitLabModel{Token: types.StringValue("glpat-2"), BaseURL: types.StringNull()},
+			state:     &VCSConnectorGitLabModel{Token: types.StringValue("glpat-1"), BaseURL: types.StringNull()},
+			wantToken: ptr("glpat-2"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			data := VCSConnectorResourceModel{Description: types.StringNull(), GitLab: &c.data}
+
+			var state *VCSConnectorResourceModel
+			if c.state != nil {
+				state = &VCSConnectorResourceModel{Description: types.StringNull(), GitLab: c.state}
+			}
+
+			var diags diag.Diagnostics
+			connector := r.modelToRequest(ctx, tfsdk.Config{}, data, state, &diags)
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+
+			if !reflect.DeepEqual(connector.GetToken(), c.wantToken) {
+				t.Errorf("Expected token %v, got %v", deref(c.wantToken), deref(connector.GetToken()))
+			}
+			if !reflect.DeepEqual(connector.GetBaseUrl(), c.wantBaseURL) {
+				t.Errorf("Expected base URL %v, got %v", deref(c.wantBaseURL), deref(connector.GetBaseUrl()))
+			}
+			if !reflect.DeepEqual(connector.GetProvider(), c.wantType) {
+				t.Errorf("Expected provider %v, got %v", deref(c.wantType), deref(connector.GetProvider()))
+			}
+		})
+	}
+}
+
+func TestVCSConnectorResourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&VCSConnectorResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	prior := VCSConnectorResourceModel{
+		ID:          types.StringValue("connector-1"),
+		Description: types.StringValue("managed by terraform"),
+		GitHub: &VCSConnectorGitHubModel{
+			Token:          types.StringValue("secret"),
+			TokenWO:        types.StringNull(),
+			TokenWOVersion: types.Int64Null(),
+			BaseURL:        types.StringNull(),
+			CACertPEM:      types.StringNull(),
+			AppID:          types.Int64Null(),
+			InstallationID: types.Int64Null(),
+			PrivateKeyPEM:  types.StringNull(),
+		},
+		Verify:     types.BoolValue(false),
+		VerifiedAt: types.StringNull(),
+		Scopes:     types.ListNull(types.StringType),
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &prior); diags.HasError() {
+		t.Fatalf("Could not set the state: %v", diags)
+	}
+
+	t.Run("drift", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/vcs_connectors/connector-1", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, `{"data":{"id":"connector-1","provider":"github","description":"changed in the UI"}}`)
+		})
+		r := &VCSConnectorResource{client: newTestSDK(t, mux)}
+
+		resp := &resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+		var data VCSConnectorResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+		}
+
+		if data.Description.ValueString() != "changed in the UI" {
+			t.Fatalf("Expected the description to be refreshed, got %s", data.Description)
+		}
+		if data.GitHub == nil || data.GitHub.Token.ValueString() != "secret" {
+			t.Fatalf("Expected the token to be kept from state, got %+v", data.GitHub)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/vcs_connectors/connector-1", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusNotFound, `{"errors":[{"title":"Not found","detail":"The VCS connector could not be found"}]}`)
+		})
+		r := &VCSConnectorResource{client: newTestSDK(t, mux)}
+
+		resp := &resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+		}
+		if !resp.State.Raw.IsNull() {
+			t.Fatal("Expected the connector to be removed from state")
+		}
+	})
+}
+
+func TestVCSConnectorResourceUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&VCSConnectorResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	github := func(token, tokenWO types.String, tokenWOVersion types.Int64) *VCSConnectorGitHubModel {
+		return &VCSConnectorGitHubModel{
+			Token:          token,
+			TokenWO:        tokenWO,
+			TokenWOVersion: tokenWOVersion,
+			BaseURL:        types.StringNull(),
+			CACertPEM:      types.StringNull(),
+			AppID:          types.Int64Null(),
+			InstallationID: types.Int64Null(),
+			PrivateKeyPEM:  types.StringNull(),
+		}
+	}
+	newState := func(t *testing.T, description types.String, github *VCSConnectorGitHubModel) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		data := VCSConnectorResourceModel{
+			ID:          types.StringValue("connector-1"),
+			Description: description,
+			GitHub:      github,
+			Verify:      types.BoolValue(false),
+			VerifiedAt:  types.StringNull(),
+			Scopes:      types.ListNull(types.StringType),
+		}
+		if diags := state.Set(ctx, &data); diags.HasError() {
+			t.Fatalf("Could not set the state: %v", diags)
+		}
+		return state
+	}
+
+	cases := map[string]struct {
+		state  tfsdk.State
+		plan   tfsdk.State
+		config tfsdk.State
+		want   map[string]any
+	}{
+		"description cleared": {
+			state:  newState(t, types.StringValue("old"), github(types.StringValue("ghp-1"), types.StringNull(), types.Int64Null())),
+			plan:   newState(t, types.StringNull(), github(types.StringValue("ghp-1"), types.StringNull(), types.Int64Null())),
+			config: newState(t, types.StringNull(), github(types.StringValue("ghp-1"), types.StringNull(), types.Int64Null())),
+			want:   map[string]any{"description": "", "ca_certificate": ""},
+		},
+		"token rotated": {
+			state:  newState(t, types.StringValue("test"), github(types.StringValue("ghp-1"), types.StringNull(), types.Int64Null())),
+			plan:   newState(t, types.StringValue("test"), github(types.StringValue("ghp-2"), types.StringNull(), types.Int64Null())),
+			config: newState(t, types.StringValue("test"), github(types.StringValue("ghp-2"), types.StringNull(), types.Int64Null())),
+			want:   map[string]any{"description": "test", "ca_certificate": "", "token": "ghp-2"},
+		},
+		"write-only token rotated": {
+			state:  newState(t, types.StringValue("test"), github(types.StringNull(), types.StringNull(), types.Int64Value(1))),
+			plan:   newState(t, types.StringValue("test"), github(types.StringNull(), types.StringNull(), types.Int64Value(2))),
+			config: newState(t, types.StringValue("test"), github(types.StringNull(), types.StringValue("ghp-wo"), types.Int64Value(2))),
+			want:   map[string]any{"description": "test", "ca_certificate": "", "token": "ghp-wo"},
+		},
+		"write-only token not rotated": {
+			state:  newState(t, types.StringValue("old"), github(types.StringNull(), types.StringNull(), types.Int64Value(1))),
+			plan:   newState(t, types.StringValue("test"), github(types.StringNull(), types.StringNull(), types.Int64Value(1))),
+			config: newState(t, types.StringValue("test"), github(types.StringNull(), types.StringValue("ghp-wo"), types.Int64Value(1))),
+			want:   map[string]any{"description": "test", "ca_certificate": ""},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var body []byte
+			mux := http.NewServeMux()
+			mux.HandleFunc("PATCH /api/vcs_connectors/connector-1", func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				writeJSON(w, http.StatusOK, `{"data":{"id":"connector-1","provider":"github"}}`)
+			})
+			r := &VCSConnectorResource{client: newTestSDK(t, mux)}
+
+			resp := &resource.UpdateResponse{State: c.state}
+			r.Update(ctx, resource.UpdateRequest{
+				State:  c.state,
+				Plan:   tfsdk.Plan(c.plan),
+				Config: tfsdk.Config(c.config),
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+			}
+
+			if got := requestObject(t, body, "description"); !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("Expected the connector %v to be sent, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+// requestObject decodes a request body and returns the object holding the
+// key, wherever the SDK nests it in the request envelope.
+func requestObject(t *testing.T, body []byte, key string) map[string]any {
+	t.Helper()
+
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Could not decode the request body %q: %v", body, err)
+	}
+
+	var find func(value any) map[string]any
+	find = func(value any) map[string]any {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if _, ok := object[key]; ok {
+			return object
+		}
+		for _, nested := range object {
+			if found := find(nested); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+
+	return find(decoded)
+}
+
+func TestVCSConnectorResourceModelToRequestBitbucket(t *testing.T) {
+	ctx := context.Background()
+	r := &VCSConnectorResource{}
+
+	appPassword := VCSConnectorBitbucketModel{
+		Workspace:   types.StringValue("acme"),
+		Username:    types.StringValue("ci-bot"),
+		AppPassword: types.StringValue("app-1"),
+		AccessToken: types.StringNull(),
+		ServerURL:   types.StringNull(),
+	}
+	accessToken := VCSConnectorBitbucketModel{
+		Workspace:   types.StringValue("ACME"),
+		Username:    types.StringNull(),
+		AppPassword: types.StringNull(),
+		AccessToken: types.StringValue("token-1"),
+		ServerURL:   types.StringValue("http://bitbucket.example.com"),
+	}
+	rotated := appPassword
+	rotated.AppPassword = types.StringValue("app-2")
+
+	cases := map[string]struct {
+		data          VCSConnectorBitbucketModel
+		state         *VCSConnectorBitbucketModel
+		wantUsername  *string
+		wantToken     *string
+		wantServerURL *string
+		wantType      *string
+	}{
+		"create on Bitbucket Cloud": {
+			data:         appPassword,
+			wantUsername: ptr("ci-bot"),
+			wantToken:    ptr("app-1"),
+			wantType:     ptr(vcsProviderBitbucket),
+		},
+		"create on Data Center": {
+			data:          accessToken,
+			wantToken:     ptr("token-1"),
+			wantServerURL: ptr("http://bitbucket.example.com"),
+			wantType:      ptr(vcsProviderBitbucket),
+		},
+		"update without rotation": {
+			data:  appPassword,
+			state: &appPassword,
+		},
+		"update with rotation": {
+			data:         rotated,
+			state:        &appPassword,
+			wantUsername: ptr("ci-bot"),
+			wantToken:    ptr("app-2"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			data := VCSConnectorResourceModel{Description: types.StringNull(), Bitbucket: &c.data}
+
+			var state *VCSConnectorResourceModel
+			if c.state != nil {
+				state = &VCSConnectorResourceModel{Description: types.StringNull(), Bitbucket: c.state}
+			}
+
+			var diags diag.Diagnostics
+			connector := r.modelToRequest(ctx, tfsdk.Config{}, data, state, &diags)
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+
+			if got := deref(connector.GetWorkspace()); got != c.data.Workspace.ValueString() {
+				t.Errorf("Expected workspace %s, got %s", c.data.Workspace, got)
+			}
+			if !reflect.DeepEqual(connector.GetUsername(), c.wantUsername) {
+				t.Errorf("Expected username %v, got %v", deref(c.wantUsername), deref(connector.GetUsername()))
+			}
+			if !reflect.DeepEqual(connector.GetToken(), c.wantToken) {
+				t.Errorf("Expected token %v, got %v", deref(c.wantToken), deref(connector.GetToken()))
+			}
+			if !reflect.DeepEqual(connector.GetBaseUrl(), c.wantServerURL) {
+				t.Errorf("Expected server URL %v, got %v", deref(c.wantServerURL), deref(connector.GetBaseUrl()))
+			}
+			if !reflect.DeepEqual(connector.GetProvider(), c.wantType) {
+				t.Errorf("Expected provider %v, got %v", deref(c.wantType), deref(connector.GetProvider()))
+			}
+		})
+	}
+}
+
+func TestVCSConnectorResourceModelToRequestAzureDevOps(t *testing.T) {
+	ctx := context.Background()
+	r := &VCSConnectorResource{}
+
+	cases := map[string]struct {
+		data      VCSConnectorAzureDevOpsModel
+		state     *VCSConnectorAzureDevOpsModel
+		wantToken *string
+		wantType  *string
+	}{
+		"create": {
+			data:      VCSConnectorAzureDevOpsModel{OrganizationURL: types.StringValue("https://dev.azure.com/acme"), Token: types.StringValue("pat-1")},
+			wantToken: ptr("pat-1"),
+			wantType:  ptr(vcsProviderAzureDevOps),
+		},
+		"update without rotation": {
+			data:  VCSConnectorAzureDevOpsModel{OrganizationURL: types.StringValue("https://dev.azure.com/acme"), Token: types.StringValue("pat-1")},
+			state: &VCSConnectorAzureDevOpsModel{OrganizationURL: types.StringValue("https://dev.azure.com/acme"), Token: types.StringValue("pat-1")},
+		},
+		"update with rotation": {
+			data:      VCSConnectorAzureDevOpsModel{OrganizationURL: types.StringValue("https://dev.azure.com/acme"), Token: types.StringValue("pat-2")},
+			state:     &VCSConnectorAzureDevOpsModel{OrganizationURL: types.StringValue("https://dev.azure.com/acme"), Token: types.StringValue("pat-1")},
+			wantToken: ptr("pat-2"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			data := VCSConnectorResourceModel{Description: types.StringNull(), AzureDevOps: &c.data}
+
+			var state *VCSConnectorResourceModel
+			if c.state != nil {
+				state = &VCSConnectorResourceModel{Description: types.StringNull(), AzureDevOps: c.state}
+			}
+
+			var diags diag.Diagnostics
+			connector := r.modelToRequest(ctx, tfsdk.Config{}, data, state, &diags)
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+
+			if got := deref(connector.GetBaseUrl()); got != "https://dev.azure.com/acme" {
+				t.Errorf("Expected the organization URL to be sent, got %s", got)
+			}
+			if !reflect.DeepEqual(connector.GetToken(), c.wantToken) {
+				t.Errorf("Expected token %v, got %v", deref(c.wantToken), deref(connector.GetToken()))
+			}
+			if !reflect.DeepEqual(connector.GetProvider(), c.wantType) {
+				t.Errorf("Expected provider %v, got %v", deref(c.wantType), deref(connector.GetProvider()))
+			}
+		})
+	}
+}
+
+func TestVCSConnectorResourceModelToRequestGitHubApp(t *testing.T) {
+	ctx := context.Background()
+	r := &VCSConnectorResource{}
+
+	app := func(appID, installationID int64, privateKey string) *VCSConnectorGitHubModel {
+		return &VCSConnectorGitHubModel{
+			Token:          types.StringNull(),
+			TokenWO:        types.StringNull(),
+			TokenWOVersion: types.Int64Null(),
+			AppID:          types.Int64Value(appID),
+			InstallationID: types.Int64Value(installationID),
+			PrivateKeyPEM:  types.StringValue(privateKey),
+			BaseURL:        types.StringNull(),
+			CACertPEM:      types.StringNull(),
+		}
+	}
+
+	cases := map[string]struct {
+		data               *VCSConnectorGitHubModel
+		state              *VCSConnectorGitHubModel
+		wantAppID          *int64
+		wantInstallationID *int64
+		wantPrivateKey     *string
+	}{
+		"create": {
+			data:               app(1, 2, "key-1"),
+			wantAppID:          ptr(int64(1)),
+			wantInstallationID: ptr(int64(2)),
+			wantPrivateKey:     ptr("key-1"),
+		},
+		"update without changes": {
+			data:  app(1, 2, "key-1"),
+			state: app(1, 2, "key-1"),
+		},
+		"update with a rotated private key": {
+			data:               app(1, 2, "key-2"),
+			state:              app(1, 2, "key-1"),
+			wantAppID:          ptr(int64(1)),
+			wantInstallationID: ptr(int64(2)),
+			wantPrivateKey:     ptr("key-2"),
+		},
+		"update with a new installation": {
+			data:               app(1, 3, "key-1"),
+			state:              app(1, 2, "key-1"),
+			wantAppID:          ptr(int64(1)),
+			wantInstallationID: ptr(int64(3)),
+			wantPrivateKey:     ptr("key-1"),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			data := VCSConnectorResourceModel{Description: types.StringNull(), GitHub: c.data}
+
+			var state *VCSConnectorResourceModel
+			if c.state != nil {
+				state = &VCSConnectorResourceModel{Description: types.StringNull(), GitHub: c.state}
+			}
+
+			var diags diag.Diagnostics
+			connector := r.modelToRequest(ctx, tfsdk.Config{}, data, state, &diags)
+			if diags.HasError() {
+				t.Fatalf("Unexpected error: %v", diags)
+			}
+
+			if !reflect.DeepEqual(connector.GetAppId(), c.wantAppID) {
+				t.Errorf("Expected app ID %v, got %v", c.wantAppID, connector.GetAppId())
+			}
+			if !reflect.DeepEqual(connector.GetInstallationId(), c.wantInstallationID) {
+				t.Errorf("Expected installation ID %v, got %v", c.wantInstallationID, connector.GetInstallationId())
+			}
+			if !reflect.DeepEqual(connector.GetPrivateKey(), c.wantPrivateKey) {
+				t.Errorf("Expected private key %v, got %v", deref(c.wantPrivateKey), deref(connector.GetPrivateKey()))
+			}
+			if connector.GetToken() != nil {
+				t.Errorf("Expected no token to be sent, got %v", deref(connector.GetToken()))
+			}
+		})
+	}
+}
+
+func TestVCSConnectorResourceResponseToModel(t *testing.T) {
+	r := &VCSConnectorResource{}
+
+	t.Run("bitbucket", func(t *testing.T) {
+		response := models.NewVCSConnector()
+		response.SetId(ptr("connector-1"))
+		response.SetProvider(ptr(vcsProviderBitbucket))
+		response.SetWorkspace(ptr("acme"))
+		response.SetUsername(ptr("ci-bot"))
+		response.SetBaseUrl(ptr("http://bitbucket.example.com"))
+
+		// The credentials are kept from state
+		data := VCSConnectorResourceModel{
+			Bitbucket: &VCSConnectorBitbucketModel{
+				Workspace:   types.StringValue("acme"),
+				Username:    types.StringValue("ci-bot"),
+				AppPassword: types.StringValue("app-1"),
+				AccessToken: types.StringNull(),
+				ServerURL:   types.StringNull(),
+			},
+		}
+		r.responseToModel(response, &data)
+
+		want := &VCSConnectorBitbucketModel{
+			Workspace:   types.StringValue("acme"),
+			Username:    types.StringValue("ci-bot"),
+			AppPassword: types.StringValue("app-1"),
+			AccessToken: types.StringNull(),
+			ServerURL:   types.StringValue("http://bitbucket.example.com"),
+		}
+		if !reflect.DeepEqual(data.Bitbucket, want) {
+			t.Fatalf("Expected %+v, got %+v", want, data.Bitbucket)
+		}
+		if data.GitHub != nil || data.AzureDevOps != nil {
+			t.Fatalf("Expected only the bitbucket block, got %+v", data)
+		}
+	})
+
+	t.Run("github app", func(t *testing.T) {
+		response := models.NewVCSConnector()
+		response.SetId(ptr("connector-1"))
+		response.SetProvider(ptr(vcsProviderGitHub))
+		response.SetAppId(ptr(int64(1)))
+		response.SetInstallationId(ptr(int64(3)))
+
+		// The private key is write-only in the API, so it is kept from state
+		data := VCSConnectorResourceModel{
+			GitHub: &VCSConnectorGitHubModel{
+				Token:          types.StringNull(),
+				TokenWO:        types.StringNull(),
+				TokenWOVersion: types.Int64Null(),
+				AppID:          types.Int64Value(1),
+				InstallationID: types.Int64Value(2),
+				PrivateKeyPEM:  types.StringValue("key-1"),
+			},
+		}
+		r.responseToModel(response, &data)
+
+		want := &VCSConnectorGitHubModel{
+			Token:          types.StringNull(),
+			TokenWO:        types.StringNull(),
+			TokenWOVersion: types.Int64Null(),
+			AppID:          types.Int64Value(1),
+			InstallationID: types.Int64Value(3),
+			PrivateKeyPEM:  types.StringValue("key-1"),
+			BaseURL:        types.StringNull(),
+			CACertPEM:      types.StringNull(),
+		}
+		if !reflect.DeepEqual(data.GitHub, want) {
+			t.Fatalf("Expected %+v, got %+v", want, data.GitHub)
+		}
+	})
+
+	t.Run("azure_devops after import", func(t *testing.T) {
+		response := models.NewVCSConnector()
+		response.SetId(ptr("connector-1"))
+		response.SetProvider(ptr(vcsProviderAzureDevOps))
+		response.SetBaseUrl(ptr("https://dev.azure.com/acme"))
+
+		var data VCSConnectorResourceModel
+		r.responseToModel(response, &data)
+
+		want := &VCSConnectorAzureDevOpsModel{
+			OrganizationURL: types.StringValue("https://dev.azure.com/acme"),
+			Token:           types.StringNull(),
+		}
+		if !reflect.DeepEqual(data.AzureDevOps, want) {
+			t.Fatalf("Expected %+v, got %+v", want, data.AzureDevOps)
+		}
+		if data.Bitbucket != nil {
+			t.Fatalf("Expected no bitbucket block, got %+v", data.Bitbucket)
+		}
+	})
+}
+
+func TestVCSConnectorResourceReadGitLab(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/vcs_connectors/connector-1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"data":{"id":"connector-1","provider":"gitlab","description":"self-managed","base_url":"https://gitlab.example.com"}}`)
+	})
+	r := &VCSConnectorResource{client: newTestSDK(t, mux)}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	read := func(t *testing.T, state tfsdk.State) VCSConnectorResourceModel {
+		resp := &resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+		var data VCSConnectorResourceModel
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+		}
+		return data
+	}
+
+	// The state after an import, which only has the ID
+	imported := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := imported.SetAttribute(ctx, path.Root("id"), "connector-1"); diags.HasError() {
+		t.Fatalf("Could not set the id: %v", diags)
+	}
+
+	data := read(t, imported)
+	if data.GitLab == nil || data.GitLab.BaseURL.ValueString() != "https://gitlab.example.com" || !data.GitLab.Token.IsNull() {
+		t.Fatalf("Expected a gitlab block with the base URL and no token, got %+v", data.GitLab)
+	}
+	if data.GitHub != nil || data.Description.ValueString() != "self-managed" {
+		t.Fatalf("Unexpected connector %+v", data)
+	}
+
+	// The token is write-only in the API, so it is kept from state
+	data.GitLab.Token = types.StringValue("glpat-1")
+	data.GitLab.BaseURL = types.StringNull()
+
+	managed := imported
+	if diags := managed.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("Could not set the state: %v", diags)
+	}
+
+	data = read(t, managed)
+	if data.GitLab.Token.ValueString() != "glpat-1" || data.GitLab.BaseURL.ValueString() != "https://gitlab.example.com" {
+		t.Fatalf("Expected the token to be kept and the base URL refreshed, got %+v", data.GitLab)
+	}
+}
+
+func TestVCSConnectorResourceReadGitPollInterval(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&VCSConnectorResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	cases := map[string]struct {
+		response string
+		want     string
+	}{
+		"omitted":    {response: `{"data":{"id":"connector-1","provider":"git","clone_url":"https://git.example.com/repo.git"}}`, want: "5m"},
+		"seconds":    {response: `{"data":{"id":"connector-1","provider":"git","clone_url":"https://git.example.com/repo.git","poll_interval":"300s"}}`, want: "5m"},
+		"normalized": {response: `{"data":{"id":"connector-1","provider":"git","clone_url":"https://git.example.com/repo.git","poll_interval":"5m0s"}}`, want: "5m"},
+		"changed":    {response: `{"data":{"id":"connector-1","provider":"git","clone_url":"https://git.example.com/repo.git","poll_interval":"10m0s"}}`, want: "10m0s"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/vcs_connectors/connector-1", func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, c.response)
+			})
+			r := &VCSConnectorResource{client: newTestSDK(t, mux)}
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			prior := VCSConnectorResourceModel{
+				ID:          types.StringValue("connector-1"),
+				Description: types.StringNull(),
+				Git: &VCSConnectorGitModel{
+					CloneURL:      types.StringValue("https://git.example.com/repo.git"),
+					Username:      types.StringNull(),
+					Password:      types.StringNull(),
+					SSHPrivateKey: types.StringNull(),
+					KnownHosts:    types.StringNull(),
+					PollInterval:  types.StringValue("5m"),
+				},
+				Verify:     types.BoolValue(false),
+				VerifiedAt: types.StringNull(),
+				Scopes:     types.ListNull(types.StringType),
+			}
+			if diags := state.Set(ctx, &prior); diags.HasError() {
+				t.Fatalf("Could not set the state: %v", diags)
+			}
+
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+			var data VCSConnectorResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected error: %v", resp.Diagnostics)
+			}
+
+			if got := data.Git.PollInterval.ValueString(); got != c.want {
+				t.Fatalf("Expected poll_interval %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return "<nil>"
+	}
+	return *value
+}
+
+func TestVCSConnectorResourceSchemaURLValidators(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&VCSConnectorResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// Only GitHub Enterprise Server requires HTTPS, so existing self-hosted
+	// HTTP instances of the other types keep working
+	cases := []struct {
+		attribute string
+		value     string
+		wantError bool
+	}{
+		{attribute: "github.base_url", value: "https://github.example.com"},
+		{attribute: "github.base_url", value: "http://github.example.com", wantError: true},
+		{attribute: "gitlab.base_url", value: "http://gitlab.example.com"},
+		{attribute: "gitlab.base_url", value: "gitlab.example.com", wantError: true},
+	}
+
+	for _, c := range cases {
+		block, attr, _ := strings.Cut(c.attribute, ".")
+		attributePath := path.Root(block).AtName(attr)
+
+		attribute, diags := schemaResp.Schema.AttributeAtPath(ctx, attributePath)
+		if diags.HasError() {
+			t.Fatalf("Could not find %s: %v", c.attribute, diags)
+		}
+
+		req := validator.StringRequest{Path: attributePath, ConfigValue: types.StringValue(c.value)}
+		resp := &validator.StringResponse{}
+		for _, v := range attribute.(schema.StringAttribute).Validators {
+			v.ValidateString(ctx, req, resp)
+		}
+
+		if resp.Diagnostics.HasError() != c.wantError {
+			t.Errorf("Expected error %t for %s = %q, got %v", c.wantError, c.attribute, c.value, resp.Diagnostics)
+		}
+	}
+}